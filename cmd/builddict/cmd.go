@@ -0,0 +1,263 @@
+package builddict
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/dict/builder"
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/japanese"
+)
+
+// subcommand property
+const (
+	CommandName  = "build-dict"
+	Description  = `build a system dictionary from MeCab sources`
+	usageMessage = "%s -src source_dir -output dict_file [-type (ipa|uni)] [-encoding (euc-jp|shift_jis|utf-8)] [-name dict_name] [-shrink]"
+)
+
+var (
+	// Stdout is the standard writer.
+	Stdout io.Writer = os.Stdout
+	// Stderr is the standard error writer.
+	Stderr io.Writer = os.Stderr
+)
+
+// options
+type option struct {
+	src      string
+	output   string
+	typ      string
+	encoding string
+	name     string
+	shrink   bool
+	flagSet  *flag.FlagSet
+}
+
+// ContinueOnError ErrorHandling // Return a descriptive error.
+// ExitOnError                   // Call os.Exit(2).
+// PanicOnError                  // Call panic with a descriptive error.flag.ContinueOnError
+func newOption(w io.Writer, eh flag.ErrorHandling) (o *option) {
+	o = &option{
+		flagSet: flag.NewFlagSet(CommandName, eh),
+	}
+	// option settings
+	o.flagSet.SetOutput(w)
+	o.flagSet.StringVar(&o.src, "src", "", "source directory (*.csv, matrix.def, char.def, unk.def)")
+	o.flagSet.StringVar(&o.output, "output", "", "output dictionary file")
+	o.flagSet.StringVar(&o.typ, "type", "ipa", "source layout type (ipa|uni)")
+	o.flagSet.StringVar(&o.encoding, "encoding", "", "source encoding (euc-jp|shift_jis|utf-8), defaults to the encoding of the layout type")
+	o.flagSet.StringVar(&o.name, "name", "", "dictionary name")
+	o.flagSet.BoolVar(&o.shrink, "shrink", false, "build a dictionary without contents for the -simple option")
+	return
+}
+
+func (o *option) parse(args []string) error {
+	if err := o.flagSet.Parse(args); err != nil {
+		return err
+	}
+	// validations
+	if nonFlag := o.flagSet.Args(); len(nonFlag) != 0 {
+		return fmt.Errorf("invalid argument: %v", nonFlag)
+	}
+	if o.src == "" {
+		return errors.New("source directory is not specified")
+	}
+	if o.output == "" {
+		return errors.New("output file is not specified")
+	}
+	if _, ok := layouts[o.typ]; !ok {
+		return fmt.Errorf("invalid argument: -type %v", o.typ)
+	}
+	if o.encoding != "" {
+		if _, ok := encodings[strings.ToLower(o.encoding)]; !ok {
+			return fmt.Errorf("invalid argument: -encoding %v", o.encoding)
+		}
+	}
+	return nil
+}
+
+// OptionCheck receives a slice of args and returns an error if it was not successfully parsed
+func OptionCheck(args []string) error {
+	opt := newOption(io.Discard, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return nil
+}
+
+// layout represents the record format of a MeCab dictionary source.
+type layout struct {
+	morph    builder.MorphRecordInfo
+	unk      builder.UnkRecordInfo
+	encoding string
+}
+
+var layouts = map[string]layout{
+	"ipa": {
+		morph: builder.MorphRecordInfo{
+			ColSize:                 13,
+			SurfaceIndex:            0,
+			LeftIDIndex:             1,
+			RightIDIndex:            2,
+			WeightIndex:             3,
+			POSStartIndex:           4,
+			OtherContentsStartIndex: 8,
+			Meta: dict.ContentsMeta{
+				dict.POSStartIndex:      0,
+				dict.POSHierarchy:       4,
+				dict.InflectionalType:   4,
+				dict.InflectionalForm:   5,
+				dict.BaseFormIndex:      6,
+				dict.ReadingIndex:       7,
+				dict.PronunciationIndex: 8,
+			},
+		},
+		unk: builder.UnkRecordInfo{
+			ColSize:                 11,
+			CategoryIndex:           0,
+			LeftIDIndex:             1,
+			RightIndex:              2,
+			WeigthIndex:             3,
+			POSStartIndex:           4,
+			OtherContentsStartIndex: 10,
+		},
+		encoding: "euc-jp",
+	},
+	"uni": {
+		morph: builder.MorphRecordInfo{
+			ColSize:                 21,
+			SurfaceIndex:            0,
+			LeftIDIndex:             1,
+			RightIDIndex:            2,
+			WeightIndex:             3,
+			POSStartIndex:           4,
+			OtherContentsStartIndex: 8,
+			Meta: dict.ContentsMeta{
+				dict.POSStartIndex:      0,
+				dict.POSHierarchy:       4,
+				dict.InflectionalType:   4,
+				dict.InflectionalForm:   5,
+				dict.BaseFormIndex:      10,
+				dict.PronunciationIndex: 9,
+			},
+		},
+		unk: builder.UnkRecordInfo{
+			ColSize:                 10,
+			CategoryIndex:           0,
+			LeftIDIndex:             1,
+			RightIndex:              2,
+			WeigthIndex:             3,
+			POSStartIndex:           4,
+			OtherContentsStartIndex: 8,
+		},
+		encoding: "utf-8",
+	},
+}
+
+var encodings = map[string]encoding.Encoding{
+	"euc-jp":    japanese.EUCJP,
+	"shift_jis": japanese.ShiftJIS,
+	"utf-8":     nil,
+}
+
+func command(_ context.Context, opt *option) error {
+	l := layouts[opt.typ]
+	name := opt.encoding
+	if name == "" {
+		name = l.encoding
+	}
+	enc, ok := encodings[strings.ToLower(name)]
+	if !ok {
+		return fmt.Errorf("unknown encoding, %v", name)
+	}
+	morph, unk := l.morph, l.unk
+	c := builder.NewConfig(opt.src, nil, enc, &morph, &unk)
+	c.AddDictInfo(&dict.Info{
+		Name: opt.name,
+		Src:  filepath.Base(filepath.Clean(opt.src)),
+	})
+	d, err := builder.Build(c)
+	if err != nil {
+		return fmt.Errorf("build error, %w", err)
+	}
+	f, err := os.OpenFile(opt.output, os.O_RDWR|os.O_TRUNC|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := save(f, d, opt.shrink); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(Stdout, "%s: %d entries\n", opt.output, len(d.Morphs))
+	return nil
+}
+
+// save writes a dictionary in the zipped format that dict.LoadDictFile reads.
+// The shrink variant omits the contents part, so it is meant to be loaded with
+// dict.LoadShrink.
+func save(w io.Writer, d *dict.Dict, shrink bool) error {
+	if !shrink {
+		zw := zip.NewWriter(w)
+		if err := d.Save(zw); err != nil {
+			return err
+		}
+		return zw.Close()
+	}
+	var b bytes.Buffer
+	zw := zip.NewWriter(&b)
+	if err := d.Save(zw); err != nil {
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		return err
+	}
+	zr, err := zip.NewReader(bytes.NewReader(b.Bytes()), int64(b.Len()))
+	if err != nil {
+		return err
+	}
+	zw = zip.NewWriter(w)
+	for _, f := range zr.File {
+		if f.Name == dict.ContentDictFileName {
+			continue
+		}
+		if err := zw.Copy(f); err != nil {
+			return fmt.Errorf("copy error, %q, %w", f.Name, err)
+		}
+	}
+	return zw.Close()
+}
+
+// Run receives the slice of args and executes the build-dict tool
+func Run(ctx context.Context, args []string) error {
+	opt := newOption(Stderr, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		Usage()
+		PrintDefaults(flag.ContinueOnError)
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return command(ctx, opt)
+}
+
+// Usage provides information on the use of the build-dict tool
+func Usage() {
+	fmt.Fprintf(Stderr, usageMessage+"\n", CommandName)
+}
+
+// PrintDefaults prints out the default flags
+func PrintDefaults(eh flag.ErrorHandling) {
+	o := newOption(Stderr, eh)
+	o.flagSet.PrintDefaults()
+}
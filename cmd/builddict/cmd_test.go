@@ -0,0 +1,157 @@
+package builddict
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+const testSrcPath = "./testdata/src"
+
+func TestOptionCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name:    "empty args",
+			args:    []string{},
+			wantErr: true,
+		},
+		{
+			name:    "no output",
+			args:    []string{"-src", testSrcPath},
+			wantErr: true,
+		},
+		{
+			name:    "unknown option",
+			args:    []string{"-src", testSrcPath, "-output", "out.dict", "-flag", "piyo"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid type",
+			args:    []string{"-src", testSrcPath, "-output", "out.dict", "-type", "piyo"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid encoding",
+			args:    []string{"-src", testSrcPath, "-output", "out.dict", "-encoding", "piyo"},
+			wantErr: true,
+		},
+		{
+			name: "all options",
+			args: []string{
+				"-src", testSrcPath,
+				"-output", "out.dict",
+				"-type", "uni",
+				"-encoding", "UTF-8",
+				"-name", "test",
+				"-shrink",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := OptionCheck(tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("OptionCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+	var b bytes.Buffer
+	stdout := Stdout
+	Stdout = &b
+	defer func() {
+		Stdout = stdout
+	}()
+
+	dir := t.TempDir()
+	full := filepath.Join(dir, "full.dict")
+	if err := command(context.TODO(), &option{
+		src:      testSrcPath,
+		output:   full,
+		typ:      "ipa",
+		encoding: "utf-8",
+		name:     "test",
+	}); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if got, want := b.String(), full+": 4 entries\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+
+	t.Run("full dictionary", func(t *testing.T) {
+		d, err := dict.LoadDictFile(full)
+		if err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		if info := d.Info(); info == nil || info.Name != "test" || info.Src != "src" {
+			t.Errorf("unexpected dict info, %+v", info)
+		}
+		tnz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+		if err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		var got [][]string
+		for _, v := range tnz.Tokenize("ねこです") {
+			got = append(got, append([]string{v.Surface}, v.Features()...))
+		}
+		want := [][]string{
+			{"ねこ", "名詞", "一般", "*", "*", "*", "*", "ねこ", "ネコ", "ネコ"},
+			{"です", "助動詞", "*", "*", "*", "特殊・デス", "基本形", "です", "デス", "デス"},
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+
+	t.Run("shrink dictionary", func(t *testing.T) {
+		shrink := filepath.Join(dir, "shrink.dict")
+		if err := command(context.TODO(), &option{
+			src:      testSrcPath,
+			output:   shrink,
+			typ:      "ipa",
+			encoding: "utf-8",
+			shrink:   true,
+		}); err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		d, err := dict.LoadShrink(shrink)
+		if err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		if d.Contents != nil {
+			t.Errorf("expected no contents, got %d records", len(d.Contents))
+		}
+		tnz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+		if err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		got := tnz.Tokenize("ねこです")
+		if len(got) != 2 {
+			t.Fatalf("got %v, want 2 tokens", got)
+		}
+		if want := []string{"名詞", "一般", "*", "*"}; !reflect.DeepEqual(got[0].Features(), want) {
+			t.Errorf("got %v, want %v", got[0].Features(), want)
+		}
+	})
+}
+
+func TestCommand_Error(t *testing.T) {
+	if err := command(context.TODO(), &option{
+		src:    "./testdata/not_found",
+		output: filepath.Join(t.TempDir(), "out.dict"),
+		typ:    "ipa",
+	}); err == nil {
+		t.Error("expected error, but no error")
+	}
+}
@@ -0,0 +1,268 @@
+package dictinfo
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome-dict/uni"
+)
+
+// subcommand property
+const (
+	CommandName  = "dictinfo"
+	Description  = `dictionary statistics and POS inventory`
+	usageMessage = "%s [-dict dic_file] [-sysdict (ipa|uni)] [-simple] [-depth n] [-json]"
+)
+
+var (
+	// Stdout is the standard writer.
+	Stdout io.Writer = os.Stdout
+	// Stderr is the standard error writer.
+	Stderr io.Writer = os.Stderr
+)
+
+// options
+type option struct {
+	dict    string
+	sysdict string
+	simple  bool
+	depth   int
+	json    bool
+	flagSet *flag.FlagSet
+}
+
+// ContinueOnError ErrorHandling // Return a descriptive error.
+// ExitOnError                   // Call os.Exit(2).
+// PanicOnError                  // Call panic with a descriptive error.flag.ContinueOnError
+func newOption(w io.Writer, eh flag.ErrorHandling) (o *option) {
+	o = &option{
+		flagSet: flag.NewFlagSet(CommandName, eh),
+	}
+	// option settings
+	o.flagSet.SetOutput(w)
+	o.flagSet.StringVar(&o.dict, "dict", "", "dict")
+	o.flagSet.StringVar(&o.sysdict, "sysdict", "ipa", "system dict type (ipa|uni)")
+	o.flagSet.BoolVar(&o.simple, "simple", false, "load the dictionary without contents")
+	o.flagSet.IntVar(&o.depth, "depth", 0, "maximum depth of the POS tree (0: unlimited)")
+	o.flagSet.BoolVar(&o.json, "json", false, "outputs in JSON format")
+	return
+}
+
+func (o *option) parse(args []string) error {
+	if err := o.flagSet.Parse(args); err != nil {
+		return err
+	}
+	// validations
+	if nonFlag := o.flagSet.Args(); len(nonFlag) != 0 {
+		return fmt.Errorf("invalid argument: %v", nonFlag)
+	}
+	if o.sysdict != "" && o.sysdict != "ipa" && o.sysdict != "uni" {
+		return fmt.Errorf("invalid argument: -sysdict %v", o.sysdict)
+	}
+	if o.depth < 0 {
+		return fmt.Errorf("invalid argument: -depth %v", o.depth)
+	}
+	return nil
+}
+
+// OptionCheck receives a slice of args and returns an error if it was not successfully parsed
+func OptionCheck(args []string) error {
+	opt := newOption(io.Discard, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return nil
+}
+
+func selectDict(path, sysdict string, shrink bool) (*dict.Dict, error) {
+	if path != "" {
+		if shrink {
+			return dict.LoadShrink(path)
+		}
+		return dict.LoadDictFile(path)
+	}
+	switch sysdict {
+	case "ipa":
+		if shrink {
+			return ipa.DictShrink(), nil
+		}
+		return ipa.Dict(), nil
+	case "uni":
+		if shrink {
+			return uni.DictShrink(), nil
+		}
+		return uni.Dict(), nil
+	}
+	return nil, fmt.Errorf("unknown dict type, %v", sysdict)
+}
+
+// Info represents the statistics of a dictionary.
+type Info struct {
+	Name         string         `json:"name"`
+	Src          string         `json:"src"`
+	Entries      int            `json:"entries"`
+	ContentsMeta []Meta         `json:"contents_meta"`
+	Connection   [2]int64       `json:"connection"`
+	POS          []*POSNode     `json:"pos"`
+	Categories   []CharCategory `json:"char_categories"`
+}
+
+// Meta represents a feature index of the dictionary contents.
+type Meta struct {
+	Key   string `json:"key"`
+	Index int    `json:"index"`
+}
+
+// POSNode represents a node of the POS hierarchy tree.
+type POSNode struct {
+	Name     string     `json:"name"`
+	Count    int        `json:"count"`
+	Children []*POSNode `json:"children,omitempty"`
+}
+
+// CharCategory represents a character category and its unknown word classes.
+type CharCategory struct {
+	Name    string     `json:"name"`
+	Invoke  bool       `json:"invoke"`
+	Group   bool       `json:"group"`
+	Unknown [][]string `json:"unknown"`
+}
+
+// NewInfo collects the statistics of a dictionary.
+// If depth > 0, the POS tree is truncated at the depth.
+func NewInfo(d *dict.Dict, depth int) *Info {
+	ret := Info{
+		Entries:    len(d.Morphs),
+		Connection: [2]int64{d.Connection.Row, d.Connection.Col},
+	}
+	if info := d.Info(); info != nil {
+		ret.Name, ret.Src = info.Name, info.Src
+	}
+	for k, v := range d.ContentsMeta {
+		ret.ContentsMeta = append(ret.ContentsMeta, Meta{Key: k, Index: int(v)})
+	}
+	sort.Slice(ret.ContentsMeta, func(i, j int) bool {
+		if ret.ContentsMeta[i].Index != ret.ContentsMeta[j].Index {
+			return ret.ContentsMeta[i].Index < ret.ContentsMeta[j].Index
+		}
+		return ret.ContentsMeta[i].Key < ret.ContentsMeta[j].Key
+	})
+	root := &POSNode{}
+	for _, pos := range d.POSTable.POSs {
+		n := root
+		for i, id := range pos {
+			if depth > 0 && i >= depth {
+				break
+			}
+			name := d.POSTable.NameList[id]
+			if name == "*" {
+				break
+			}
+			n = n.child(name)
+			n.Count++
+		}
+	}
+	ret.POS = root.Children
+	for i, name := range d.CharClass {
+		c := CharCategory{Name: name}
+		if i < len(d.InvokeList) {
+			c.Invoke = d.InvokeList[i]
+		}
+		if i < len(d.GroupList) {
+			c.Group = d.GroupList[i]
+		}
+		if id, ok := d.UnkDict.Index[int32(i)]; ok {
+			for x := int32(0); x <= d.UnkDict.IndexDup[int32(i)]; x++ {
+				c.Unknown = append(c.Unknown, d.UnkDict.Contents[id+x])
+			}
+		}
+		ret.Categories = append(ret.Categories, c)
+	}
+	return &ret
+}
+
+func (n *POSNode) child(name string) *POSNode {
+	for _, v := range n.Children {
+		if v.Name == name {
+			return v
+		}
+	}
+	ret := &POSNode{Name: name}
+	n.Children = append(n.Children, ret)
+	return ret
+}
+
+func command(_ context.Context, opt *option) error {
+	d, err := selectDict(opt.dict, opt.sysdict, opt.simple)
+	if err != nil {
+		return err
+	}
+	info := NewInfo(d, opt.depth)
+	if opt.json {
+		b, err := json.Marshal(info)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(Stdout, string(b))
+		return nil
+	}
+	w := bufio.NewWriter(Stdout)
+	defer w.Flush()
+	fmt.Fprintf(w, "name: %s\n", info.Name)
+	fmt.Fprintf(w, "source: %s\n", info.Src)
+	fmt.Fprintf(w, "entries: %d\n", info.Entries)
+	fmt.Fprintf(w, "connection matrix: %d x %d\n", info.Connection[0], info.Connection[1])
+	fmt.Fprintln(w, "contents meta:")
+	for _, v := range info.ContentsMeta {
+		fmt.Fprintf(w, "  %s\t%d\n", v.Key, v.Index)
+	}
+	fmt.Fprintln(w, "pos:")
+	printPOSTree(w, 1, info.POS)
+	fmt.Fprintln(w, "char categories:")
+	for _, v := range info.Categories {
+		fmt.Fprintf(w, "  %s\tinvoke=%v\tgroup=%v\n", v.Name, v.Invoke, v.Group)
+		for _, u := range v.Unknown {
+			fmt.Fprintf(w, "    %s\n", strings.Join(u, ","))
+		}
+	}
+	return nil
+}
+
+func printPOSTree(w io.Writer, indent int, nodes []*POSNode) {
+	const space = `  `
+	for _, v := range nodes {
+		fmt.Fprintf(w, "%s%s\t%d\n", strings.Repeat(space, indent), v.Name, v.Count)
+		printPOSTree(w, indent+1, v.Children)
+	}
+}
+
+// Run receives the slice of args and executes the dictinfo tool
+func Run(ctx context.Context, args []string) error {
+	opt := newOption(Stderr, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		Usage()
+		PrintDefaults(flag.ContinueOnError)
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return command(ctx, opt)
+}
+
+// Usage provides information on the use of the dictinfo tool
+func Usage() {
+	fmt.Fprintf(Stderr, usageMessage+"\n", CommandName)
+}
+
+// PrintDefaults prints out the default flags
+func PrintDefaults(eh flag.ErrorHandling) {
+	o := newOption(Stderr, eh)
+	o.flagSet.PrintDefaults()
+}
@@ -0,0 +1,137 @@
+package dictinfo
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+)
+
+const testDictPath = "../../testdata/ipa.dict"
+
+func TestOptionCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name:    "no options",
+			args:    []string{},
+			wantErr: false,
+		},
+		{
+			name:    "non flag options",
+			args:    []string{"piyo"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid sysdict",
+			args:    []string{"-sysdict", "piyo"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid depth",
+			args:    []string{"-depth", "-1"},
+			wantErr: true,
+		},
+		{
+			name:    "all options",
+			args:    []string{"-dict", testDictPath, "-sysdict", "uni", "-simple", "-depth", "2", "-json"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := OptionCheck(tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("OptionCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNewInfo(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	info := NewInfo(d, 2)
+	if got, want := info.Entries, len(d.Morphs); got != want {
+		t.Errorf("got %d, want %d", got, want)
+	}
+	var total int
+	for _, v := range info.POS {
+		total += v.Count
+		for _, c := range v.Children {
+			if len(c.Children) != 0 {
+				t.Errorf("POS tree should be truncated at depth 2, %+v", c)
+			}
+		}
+	}
+	if total != info.Entries {
+		t.Errorf("sum of POS counts got %d, want %d", total, info.Entries)
+	}
+	for i := 1; i < len(info.ContentsMeta); i++ {
+		if info.ContentsMeta[i-1].Index > info.ContentsMeta[i].Index {
+			t.Errorf("contents meta is not sorted, %+v", info.ContentsMeta)
+		}
+	}
+	if got, want := len(info.Categories), len(d.CharClass); got != want {
+		t.Errorf("got %d, want %d", got, want)
+	}
+	for _, v := range info.Categories {
+		if len(v.Unknown) == 0 {
+			t.Errorf("no unknown word classes, %+v", v)
+		}
+	}
+}
+
+func TestCommand(t *testing.T) {
+	var b bytes.Buffer
+	stdout := Stdout
+	Stdout = &b
+	defer func() {
+		Stdout = stdout
+	}()
+
+	t.Run("text output", func(t *testing.T) {
+		b.Reset()
+		if err := command(context.TODO(), &option{
+			dict:  testDictPath,
+			depth: 1,
+		}); err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		for _, want := range []string{
+			"entries: ",
+			"connection matrix: ",
+			"contents meta:\n  _pos_start\t0\n",
+			"pos:\n  ",
+			"char categories:\n  DEFAULT\t",
+		} {
+			if !strings.Contains(b.String(), want) {
+				t.Errorf("output does not contain %q", want)
+			}
+		}
+	})
+	t.Run("json output", func(t *testing.T) {
+		b.Reset()
+		if err := command(context.TODO(), &option{
+			dict:   testDictPath,
+			simple: true,
+			json:   true,
+		}); err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		var got Info
+		if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		if got.Entries == 0 || len(got.POS) == 0 || len(got.Categories) == 0 {
+			t.Errorf("unexpected info, %+v", got)
+		}
+	})
+}
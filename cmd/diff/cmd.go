@@ -0,0 +1,285 @@
+package diff
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome-dict/uni"
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/ikawaha/kagome/v2/userdict"
+)
+
+// subcommand property
+const (
+	CommandName  = "diff"
+	Description  = `compare the segmentations of two tokenizer configurations`
+	usageMessage = "%s [-file input_file] [-dict dic_file] [-udict user_dic_file] [-sysdict (ipa|uni)] [-mode (normal|search|extended)]" +
+		" [-dict2 dic_file] [-udict2 user_dic_file] [-sysdict2 (ipa|uni)] [-mode2 (normal|search|extended)] [-split]"
+)
+
+var (
+	// Stdout is the standard writer.
+	Stdout io.Writer = os.Stdout
+	// Stderr is the standard error writer.
+	Stderr io.Writer = os.Stderr
+)
+
+// config represents a tokenizer configuration to compare.
+type config struct {
+	dict    string
+	udict   string
+	sysdict string
+	mode    string
+}
+
+// options
+type option struct {
+	file    string
+	a       config
+	b       config
+	split   bool
+	flagSet *flag.FlagSet
+}
+
+// ContinueOnError ErrorHandling // Return a descriptive error.
+// ExitOnError                   // Call os.Exit(2).
+// PanicOnError                  // Call panic with a descriptive error.flag.ContinueOnError
+func newOption(w io.Writer, eh flag.ErrorHandling) (o *option) {
+	o = &option{
+		flagSet: flag.NewFlagSet(CommandName, eh),
+	}
+	// option settings
+	o.flagSet.SetOutput(w)
+	o.flagSet.StringVar(&o.file, "file", "", "input file")
+	o.flagSet.StringVar(&o.a.dict, "dict", "", "dict of the 1st configuration")
+	o.flagSet.StringVar(&o.a.udict, "udict", "", "user dict of the 1st configuration")
+	o.flagSet.StringVar(&o.a.sysdict, "sysdict", "ipa", "system dict type of the 1st configuration (ipa|uni)")
+	o.flagSet.StringVar(&o.a.mode, "mode", "normal", "tokenize mode of the 1st configuration (normal|search|extended)")
+	o.flagSet.StringVar(&o.b.dict, "dict2", "", "dict of the 2nd configuration (default: same as the 1st)")
+	o.flagSet.StringVar(&o.b.udict, "udict2", "", "user dict of the 2nd configuration")
+	o.flagSet.StringVar(&o.b.sysdict, "sysdict2", "", "system dict type of the 2nd configuration (ipa|uni)")
+	o.flagSet.StringVar(&o.b.mode, "mode2", "", "tokenize mode of the 2nd configuration (default: same as the 1st)")
+	o.flagSet.BoolVar(&o.split, "split", false, "use tiny sentence splitter")
+	return
+}
+
+func (o *option) parse(args []string) error {
+	if err := o.flagSet.Parse(args); err != nil {
+		return err
+	}
+	// validations
+	if nonFlag := o.flagSet.Args(); len(nonFlag) != 0 {
+		return fmt.Errorf("invalid argument: %v", nonFlag)
+	}
+	for _, v := range []struct {
+		name, value string
+	}{
+		{name: "mode", value: o.a.mode},
+		{name: "mode2", value: o.b.mode},
+	} {
+		if v.value != "" && v.value != "normal" && v.value != "search" && v.value != "extended" {
+			return fmt.Errorf("invalid argument: -%s %v", v.name, v.value)
+		}
+	}
+	for _, v := range []struct {
+		name, value string
+	}{
+		{name: "sysdict", value: o.a.sysdict},
+		{name: "sysdict2", value: o.b.sysdict},
+	} {
+		if v.value != "" && v.value != "ipa" && v.value != "uni" {
+			return fmt.Errorf("invalid argument: -%s %v", v.name, v.value)
+		}
+	}
+	return nil
+}
+
+// OptionCheck receives a slice of args and returns an error if it was not successfully parsed
+func OptionCheck(args []string) error {
+	opt := newOption(io.Discard, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return nil
+}
+
+func selectDict(path, sysdict string) (*dict.Dict, error) {
+	if path != "" {
+		return dict.LoadDictFile(path)
+	}
+	switch sysdict {
+	case "ipa":
+		return ipa.Dict(), nil
+	case "uni":
+		return uni.Dict(), nil
+	}
+	return nil, fmt.Errorf("unknown dict type, %v", sysdict)
+}
+
+func selectMode(mode string) tokenizer.TokenizeMode {
+	switch mode {
+	case "normal":
+		return tokenizer.Normal
+	case "search":
+		return tokenizer.Search
+	case "extended":
+		return tokenizer.Extended
+	}
+	return tokenizer.Normal
+}
+
+func newTokenizer(c config) (*tokenizer.Tokenizer, error) {
+	d, err := selectDict(c.dict, c.sysdict)
+	if err != nil {
+		return nil, err
+	}
+	udict := tokenizer.Nop()
+	if c.udict != "" {
+		d, err := userdict.Load(c.udict)
+		if err != nil {
+			return nil, err
+		}
+		udict = tokenizer.UserDict(d)
+	}
+	return tokenizer.New(d, udict, tokenizer.OmitBosEos())
+}
+
+// chunk is a pair of token sequences which cover the same span of a sentence.
+type chunk struct {
+	a, b []tokenizer.Token
+}
+
+func (c chunk) equal() bool {
+	return len(c.a) == 1 && len(c.b) == 1
+}
+
+// align splits two segmentations of the same sentence into the chunks
+// delimited by the boundaries they have in common.
+func align(a, b []tokenizer.Token) []chunk {
+	var ret []chunk
+	for i, j := 0, 0; i < len(a) && j < len(b); i, j = i+1, j+1 {
+		c := chunk{a: []tokenizer.Token{a[i]}, b: []tokenizer.Token{b[j]}}
+		for a[i].End != b[j].End {
+			if a[i].End < b[j].End {
+				if i+1 == len(a) {
+					break
+				}
+				i++
+				c.a = append(c.a, a[i])
+				continue
+			}
+			if j+1 == len(b) {
+				break
+			}
+			j++
+			c.b = append(c.b, b[j])
+		}
+		ret = append(ret, c)
+	}
+	return ret
+}
+
+func command(_ context.Context, opt *option) error {
+	if opt.b.dict == "" && opt.b.sysdict == "" {
+		opt.b.dict, opt.b.sysdict = opt.a.dict, opt.a.sysdict
+	}
+	if opt.b.mode == "" {
+		opt.b.mode = opt.a.mode
+	}
+	ta, err := newTokenizer(opt.a)
+	if err != nil {
+		return err
+	}
+	tb, err := newTokenizer(opt.b)
+	if err != nil {
+		return err
+	}
+
+	fp := os.Stdin
+	if opt.file != "" {
+		var err error
+		fp, err = os.Open(opt.file)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = fp.Close()
+		}()
+	}
+	w := bufio.NewWriter(Stdout)
+	defer w.Flush()
+
+	ma, mb := selectMode(opt.a.mode), selectMode(opt.b.mode)
+	s := bufio.NewScanner(fp)
+	if opt.split {
+		s.Split(filter.ScanSentences)
+	}
+	var total, diffs int
+	for s.Scan() {
+		total++
+		chunks := align(ta.Analyze(s.Text(), ma), tb.Analyze(s.Text(), mb))
+		var differ bool
+		for _, v := range chunks {
+			if !v.equal() {
+				differ = true
+				break
+			}
+		}
+		if !differ {
+			continue
+		}
+		diffs++
+		fmt.Fprintf(w, "[%d] %s\n", total, s.Text())
+		for _, v := range chunks {
+			if v.equal() {
+				printToken(w, " ", v.a[0])
+				continue
+			}
+			for _, t := range v.a {
+				printToken(w, "-", t)
+			}
+			for _, t := range v.b {
+				printToken(w, "+", t)
+			}
+		}
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+	fmt.Fprintf(w, "%d/%d sentences differ\n", diffs, total)
+	return nil
+}
+
+func printToken(w io.Writer, mark string, t tokenizer.Token) {
+	fmt.Fprintf(w, "%s %s\t%s\n", mark, t.Surface, strings.Join(t.POS(), ","))
+}
+
+// Run receives the slice of args and executes the diff tool
+func Run(ctx context.Context, args []string) error {
+	opt := newOption(Stderr, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		Usage()
+		PrintDefaults(flag.ContinueOnError)
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return command(ctx, opt)
+}
+
+// Usage provides information on the use of the diff tool
+func Usage() {
+	fmt.Fprintf(Stderr, usageMessage+"\n", CommandName)
+}
+
+// PrintDefaults prints out the default flags
+func PrintDefaults(eh flag.ErrorHandling) {
+	o := newOption(Stderr, eh)
+	o.flagSet.PrintDefaults()
+}
@@ -0,0 +1,102 @@
+package diff
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const (
+	testDictPath     = "../../testdata/ipa.dict"
+	testUserDictPath = "../../testdata/userdict.txt"
+)
+
+func TestOptionCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name:    "no options",
+			args:    []string{},
+			wantErr: false,
+		},
+		{
+			name:    "non flag options",
+			args:    []string{"piyo"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid mode2",
+			args:    []string{"-mode2", "piyo"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid sysdict2",
+			args:    []string{"-sysdict2", "piyo"},
+			wantErr: true,
+		},
+		{
+			name: "all options",
+			args: []string{
+				"-file", "input.txt",
+				"-dict", testDictPath,
+				"-udict", testUserDictPath,
+				"-sysdict", "ipa",
+				"-mode", "normal",
+				"-dict2", testDictPath,
+				"-udict2", testUserDictPath,
+				"-sysdict2", "uni",
+				"-mode2", "search",
+				"-split",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := OptionCheck(tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("OptionCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+	var b bytes.Buffer
+	stdout := Stdout
+	Stdout = &b
+	defer func() {
+		Stdout = stdout
+	}()
+
+	in := filepath.Join(t.TempDir(), "input.txt")
+	if err := os.WriteFile(in, []byte("朝青龍です\n今日は晴れ\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if err := command(context.TODO(), &option{
+		file: in,
+		a: config{
+			dict: testDictPath,
+			mode: "normal",
+		},
+		b: config{
+			udict: testUserDictPath,
+		},
+	}); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	want := `[1] 朝青龍です
+- 朝	名詞,副詞可能,*,*
+- 青龍	名詞,固有名詞,地域,一般
++ 朝青龍	カスタム人名
+  です	助動詞,*,*,*
+1/2 sentences differ
+`
+	if got := b.String(); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,212 @@
+package eval
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome-dict/uni"
+	"github.com/ikawaha/kagome/v2/corpus"
+	"github.com/ikawaha/kagome/v2/eval"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/ikawaha/kagome/v2/userdict"
+)
+
+// subcommand property
+const (
+	CommandName  = "eval"
+	Description  = `segmentation accuracy evaluation`
+	usageMessage = "%s -corpus corpus_file [-format (mecab|conllu)] [-dict dic_file] [-udict user_dic_file]" +
+		" [-sysdict (ipa|uni)] [-mode (normal|search|extended)] [-top n]"
+)
+
+var (
+	// Stdout is the standard writer.
+	Stdout io.Writer = os.Stdout
+	// Stderr is the standard error writer.
+	Stderr io.Writer = os.Stderr
+)
+
+// options
+type option struct {
+	corpus  string
+	format  string
+	dict    string
+	udict   string
+	sysdict string
+	mode    string
+	top     int
+	flagSet *flag.FlagSet
+}
+
+// ContinueOnError ErrorHandling // Return a descriptive error.
+// ExitOnError                   // Call os.Exit(2).
+// PanicOnError                  // Call panic with a descriptive error.flag.ContinueOnError
+func newOption(w io.Writer, eh flag.ErrorHandling) (o *option) {
+	o = &option{
+		flagSet: flag.NewFlagSet(CommandName, eh),
+	}
+	// option settings
+	o.flagSet.SetOutput(w)
+	o.flagSet.StringVar(&o.corpus, "corpus", "", "gold standard corpus")
+	o.flagSet.StringVar(&o.format, "format", "mecab", "corpus format (mecab|conllu)")
+	o.flagSet.StringVar(&o.dict, "dict", "", "dict")
+	o.flagSet.StringVar(&o.udict, "udict", "", "user dict")
+	o.flagSet.StringVar(&o.sysdict, "sysdict", "ipa", "system dict type (ipa|uni)")
+	o.flagSet.StringVar(&o.mode, "mode", "normal", "tokenize mode (normal|search|extended)")
+	o.flagSet.IntVar(&o.top, "top", 20, "number of the most frequent error patterns to show")
+	return
+}
+
+func (o *option) parse(args []string) error {
+	if err := o.flagSet.Parse(args); err != nil {
+		return err
+	}
+	// validations
+	if nonFlag := o.flagSet.Args(); len(nonFlag) != 0 {
+		return fmt.Errorf("invalid argument: %v", nonFlag)
+	}
+	if o.corpus == "" {
+		return errors.New("corpus file is not specified")
+	}
+	if o.format != "mecab" && o.format != "conllu" {
+		return fmt.Errorf("invalid argument: -format %v", o.format)
+	}
+	if o.mode != "" && o.mode != "normal" && o.mode != "search" && o.mode != "extended" {
+		return fmt.Errorf("invalid argument: -mode %v", o.mode)
+	}
+	if o.sysdict != "" && o.sysdict != "ipa" && o.sysdict != "uni" {
+		return fmt.Errorf("invalid argument: -sysdict %v", o.sysdict)
+	}
+	if o.top < 0 {
+		return fmt.Errorf("invalid argument: -top %v", o.top)
+	}
+	return nil
+}
+
+// OptionCheck receives a slice of args and returns an error if it was not successfully parsed
+func OptionCheck(args []string) error {
+	opt := newOption(io.Discard, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return nil
+}
+
+func selectDict(path, sysdict string) (*dict.Dict, error) {
+	if path != "" {
+		return dict.LoadDictFile(path)
+	}
+	switch sysdict {
+	case "ipa":
+		return ipa.Dict(), nil
+	case "uni":
+		return uni.Dict(), nil
+	}
+	return nil, fmt.Errorf("unknown dict type, %v", sysdict)
+}
+
+func selectMode(mode string) tokenizer.TokenizeMode {
+	switch mode {
+	case "normal":
+		return tokenizer.Normal
+	case "search":
+		return tokenizer.Search
+	case "extended":
+		return tokenizer.Extended
+	}
+	return tokenizer.Normal
+}
+
+func readCorpus(path, format string) ([]corpus.Sentence, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	if format == "conllu" {
+		return corpus.ReadCoNLLU(f)
+	}
+	return corpus.ReadMeCab(f)
+}
+
+func command(_ context.Context, opt *option) error {
+	d, err := selectDict(opt.dict, opt.sysdict)
+	if err != nil {
+		return err
+	}
+	udict := tokenizer.Nop()
+	if opt.udict != "" {
+		d, err := userdict.Load(opt.udict)
+		if err != nil {
+			return err
+		}
+		udict = tokenizer.UserDict(d)
+	}
+	t, err := tokenizer.New(d, udict)
+	if err != nil {
+		return err
+	}
+	c, err := readCorpus(opt.corpus, opt.format)
+	if err != nil {
+		return err
+	}
+	e := eval.New(t, selectMode(opt.mode))
+	if v, ok := d.ContentsMeta[dict.POSHierarchy]; ok {
+		e.POSHierarchy = int(v)
+	}
+	for _, s := range c {
+		e.Add(s)
+	}
+	r := e.Result()
+
+	w := bufio.NewWriter(Stdout)
+	defer w.Flush()
+	fmt.Fprintf(w, "sentences: %d\n", r.Sentences)
+	printScore(w, "boundary", r.Boundaries)
+	printScore(w, "word", r.Words)
+	for i, v := range r.POS {
+		fmt.Fprintf(w, "pos level %d: accuracy %.4f (%d/%d)\n", i+1, v.Rate(), v.Correct, v.Total)
+	}
+	if opt.top == 0 {
+		return nil
+	}
+	fmt.Fprintln(w, "errors:")
+	for _, v := range r.ErrorPatterns(opt.top) {
+		fmt.Fprintf(w, "  %d\t%s\n", v.Count, v.Pattern)
+	}
+	return nil
+}
+
+func printScore(w io.Writer, name string, s eval.Score) {
+	fmt.Fprintf(w, "%s: precision %.4f, recall %.4f, f1 %.4f (gold %d, system %d, correct %d)\n",
+		name, s.Precision(), s.Recall(), s.F1(), s.Gold, s.System, s.Correct)
+}
+
+// Run receives the slice of args and executes the eval tool
+func Run(ctx context.Context, args []string) error {
+	opt := newOption(Stderr, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		Usage()
+		PrintDefaults(flag.ContinueOnError)
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return command(ctx, opt)
+}
+
+// Usage provides information on the use of the eval tool
+func Usage() {
+	fmt.Fprintf(Stderr, usageMessage+"\n", CommandName)
+}
+
+// PrintDefaults prints out the default flags
+func PrintDefaults(eh flag.ErrorHandling) {
+	o := newOption(Stderr, eh)
+	o.flagSet.PrintDefaults()
+}
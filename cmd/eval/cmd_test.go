@@ -0,0 +1,104 @@
+package eval
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testDictPath = "../../testdata/ipa.dict"
+
+func TestOptionCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name:    "empty args",
+			args:    []string{},
+			wantErr: true,
+		},
+		{
+			name:    "corpus only",
+			args:    []string{"-corpus", "gold.txt"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid format",
+			args:    []string{"-corpus", "gold.txt", "-format", "piyo"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid mode",
+			args:    []string{"-corpus", "gold.txt", "-mode", "piyo"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid top",
+			args:    []string{"-corpus", "gold.txt", "-top", "-1"},
+			wantErr: true,
+		},
+		{
+			name: "all options",
+			args: []string{
+				"-corpus", "gold.txt",
+				"-format", "conllu",
+				"-dict", testDictPath,
+				"-udict", "../../testdata/userdict.txt",
+				"-sysdict", "uni",
+				"-mode", "search",
+				"-top", "5",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := OptionCheck(tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("OptionCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+	var b bytes.Buffer
+	stdout := Stdout
+	Stdout = &b
+	defer func() {
+		Stdout = stdout
+	}()
+
+	in := filepath.Join(t.TempDir(), "gold.txt")
+	const gold = `関西	名詞,固有名詞,地域,一般,*,*,関西,カンサイ,カンサイ
+国際	名詞,一般,*,*,*,*,国際,コクサイ,コクサイ
+空港	名詞,一般,*,*,*,*,空港,クウコウ,クーコー
+です	助動詞,*,*,*,特殊・デス,基本形,です,デス,デス
+EOS
+`
+	if err := os.WriteFile(in, []byte(gold), 0o600); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if err := command(context.TODO(), &option{
+		corpus: in,
+		format: "mecab",
+		dict:   testDictPath,
+		mode:   "normal",
+		top:    10,
+	}); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	want := `sentences: 1
+boundary: precision 1.0000, recall 0.3333, f1 0.5000 (gold 3, system 1, correct 1)
+word: precision 0.5000, recall 0.2500, f1 0.3333 (gold 4, system 2, correct 1)
+pos level 1: accuracy 1.0000 (1/1)
+errors:
+  1	関西/国際/空港 -> 関西国際空港
+`
+	if got := b.String(); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,253 @@
+package grep
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome-dict/uni"
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/pattern"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/ikawaha/kagome/v2/userdict"
+)
+
+// subcommand property
+const (
+	CommandName  = "grep"
+	Description  = `search token patterns`
+	usageMessage = "%s [-file input_file] [-dict dic_file] [-udict user_dic_file] [-sysdict (ipa|uni)]" +
+		" [-mode (normal|search|extended)] [-split] [-n] [-json] pattern"
+)
+
+var (
+	// Stdout is the standard writer.
+	Stdout io.Writer = os.Stdout
+	// Stderr is the standard error writer.
+	Stderr io.Writer = os.Stderr
+)
+
+// options
+type option struct {
+	file    string
+	dict    string
+	udict   string
+	sysdict string
+	mode    string
+	split   bool
+	number  bool
+	json    bool
+	pattern *pattern.Pattern
+	flagSet *flag.FlagSet
+}
+
+// ContinueOnError ErrorHandling // Return a descriptive error.
+// ExitOnError                   // Call os.Exit(2).
+// PanicOnError                  // Call panic with a descriptive error.flag.ContinueOnError
+func newOption(w io.Writer, eh flag.ErrorHandling) (o *option) {
+	o = &option{
+		flagSet: flag.NewFlagSet(CommandName, eh),
+	}
+	// option settings
+	o.flagSet.SetOutput(w)
+	o.flagSet.StringVar(&o.file, "file", "", "input file")
+	o.flagSet.StringVar(&o.dict, "dict", "", "dict")
+	o.flagSet.StringVar(&o.udict, "udict", "", "user dict")
+	o.flagSet.StringVar(&o.sysdict, "sysdict", "ipa", "system dict type (ipa|uni)")
+	o.flagSet.StringVar(&o.mode, "mode", "normal", "tokenize mode (normal|search|extended)")
+	o.flagSet.BoolVar(&o.split, "split", false, "use tiny sentence splitter")
+	o.flagSet.BoolVar(&o.number, "n", false, "prefix each match with the line number")
+	o.flagSet.BoolVar(&o.json, "json", false, "outputs in JSON format")
+	return
+}
+
+func (o *option) parse(args []string) error {
+	if err := o.flagSet.Parse(args); err != nil {
+		return err
+	}
+	// validations
+	switch o.flagSet.NArg() {
+	case 0:
+		return errors.New("no pattern is specified")
+	case 1:
+	default:
+		return fmt.Errorf("invalid argument: %v", o.flagSet.Args()[1:])
+	}
+	if o.mode != "" && o.mode != "normal" && o.mode != "search" && o.mode != "extended" {
+		return fmt.Errorf("invalid argument: -mode %v", o.mode)
+	}
+	if o.sysdict != "" && o.sysdict != "ipa" && o.sysdict != "uni" {
+		return fmt.Errorf("invalid argument: -sysdict %v", o.sysdict)
+	}
+	p, err := pattern.Compile(o.flagSet.Arg(0))
+	if err != nil {
+		return err
+	}
+	o.pattern = p
+	return nil
+}
+
+// OptionCheck receives a slice of args and returns an error if it was not successfully parsed
+func OptionCheck(args []string) error {
+	opt := newOption(io.Discard, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return nil
+}
+
+func selectDict(path, sysdict string) (*dict.Dict, error) {
+	if path != "" {
+		return dict.LoadDictFile(path)
+	}
+	switch sysdict {
+	case "ipa":
+		return ipa.Dict(), nil
+	case "uni":
+		return uni.Dict(), nil
+	}
+	return nil, fmt.Errorf("unknown dict type, %v", sysdict)
+}
+
+func selectMode(mode string) tokenizer.TokenizeMode {
+	switch mode {
+	case "normal":
+		return tokenizer.Normal
+	case "search":
+		return tokenizer.Search
+	case "extended":
+		return tokenizer.Extended
+	}
+	return tokenizer.Normal
+}
+
+// match is the JSON output format of a match.
+type match struct {
+	Line     int               `json:"line"`
+	Surface  string            `json:"surface"`
+	Position int               `json:"position"` // byte position in the line
+	Groups   map[string]string `json:"groups,omitempty"`
+}
+
+func command(_ context.Context, opt *option) error {
+	d, err := selectDict(opt.dict, opt.sysdict)
+	if err != nil {
+		return err
+	}
+	udict := tokenizer.Nop()
+	if opt.udict != "" {
+		d, err := userdict.Load(opt.udict)
+		if err != nil {
+			return err
+		}
+		udict = tokenizer.UserDict(d)
+	}
+	t, err := tokenizer.New(d, udict, tokenizer.OmitBosEos())
+	if err != nil {
+		return err
+	}
+
+	fp := os.Stdin
+	if opt.file != "" {
+		var err error
+		fp, err = os.Open(opt.file)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = fp.Close()
+		}()
+	}
+	w := bufio.NewWriter(Stdout)
+	defer w.Flush()
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+
+	mode := selectMode(opt.mode)
+	s := bufio.NewScanner(fp)
+	if opt.split {
+		s.Split(filter.ScanSentences)
+	}
+	for line := 1; s.Scan(); line++ {
+		tokens := t.Analyze(s.Text(), mode)
+		for _, m := range opt.pattern.FindAll(tokens, -1) {
+			if len(m[0].Tokens) == 0 {
+				continue // an empty match
+			}
+			if opt.json {
+				if err := enc.Encode(newMatch(line, m)); err != nil {
+					return err
+				}
+				continue
+			}
+			if opt.number {
+				fmt.Fprintf(w, "%d:", line)
+			}
+			w.WriteString(m[0].Surface())
+			for i, g := range m[1:] {
+				if g.Start < 0 {
+					continue
+				}
+				name := g.Name
+				if name == "" {
+					name = strconv.Itoa(i + 1)
+				}
+				fmt.Fprintf(w, "\t%s=%s", name, g.Surface())
+			}
+			w.WriteString("\n")
+		}
+	}
+	return s.Err()
+}
+
+func newMatch(line int, m pattern.Match) match {
+	ret := match{
+		Line:     line,
+		Surface:  m[0].Surface(),
+		Position: m[0].Tokens[0].Position,
+	}
+	for i, g := range m[1:] {
+		if g.Start < 0 {
+			continue
+		}
+		if ret.Groups == nil {
+			ret.Groups = map[string]string{}
+		}
+		name := g.Name
+		if name == "" {
+			name = strconv.Itoa(i + 1)
+		}
+		ret.Groups[name] = g.Surface()
+	}
+	return ret
+}
+
+// Run receives the slice of args and executes the grep tool
+func Run(ctx context.Context, args []string) error {
+	opt := newOption(Stderr, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		Usage()
+		PrintDefaults(flag.ContinueOnError)
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return command(ctx, opt)
+}
+
+// Usage provides information on the use of the grep tool
+func Usage() {
+	fmt.Fprintf(Stderr, usageMessage+"\n", CommandName)
+}
+
+// PrintDefaults prints out the default flags
+func PrintDefaults(eh flag.ErrorHandling) {
+	o := newOption(Stderr, eh)
+	o.flagSet.PrintDefaults()
+}
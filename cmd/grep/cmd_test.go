@@ -0,0 +1,120 @@
+package grep
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ikawaha/kagome/v2/pattern"
+)
+
+const testDictPath = "../../testdata/ipa.dict"
+
+func TestOptionCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name:    "no pattern",
+			args:    []string{},
+			wantErr: true,
+		},
+		{
+			name:    "pattern",
+			args:    []string{"[pos:名詞]+"},
+			wantErr: false,
+		},
+		{
+			name:    "too many patterns",
+			args:    []string{"[pos:名詞]", "[pos:動詞]"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid pattern",
+			args:    []string{"[pos:名詞"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid mode",
+			args:    []string{"-mode", "piyo", "[]"},
+			wantErr: true,
+		},
+		{
+			name: "all options",
+			args: []string{
+				"-file", "input.txt",
+				"-dict", testDictPath,
+				"-udict", "../../testdata/userdict.txt",
+				"-sysdict", "uni",
+				"-mode", "search",
+				"-split",
+				"-n",
+				"-json",
+				"[pos:名詞]",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := OptionCheck(tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("OptionCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+	in := filepath.Join(t.TempDir(), "input.txt")
+	const input = `南の方の海
+赤い蝋燭と人魚
+東京の空の色
+`
+	if err := os.WriteFile(in, []byte(input), 0o600); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	p := pattern.MustCompile(`(?<left>[pos:名詞]) [surface:の] ([pos:名詞])`)
+	testdata := []struct {
+		name string
+		opt  option
+		want string
+	}{
+		{
+			name: "text",
+			opt:  option{number: true},
+			want: "1:南の方\tleft=南\t2=方\n3:東京の空\tleft=東京\t2=空\n",
+		},
+		{
+			name: "json",
+			opt:  option{json: true},
+			want: `{"line":1,"surface":"南の方","position":0,"groups":{"2":"方","left":"南"}}
+{"line":3,"surface":"東京の空","position":0,"groups":{"2":"空","left":"東京"}}
+`,
+		},
+	}
+	for _, v := range testdata {
+		t.Run(v.name, func(t *testing.T) {
+			var b bytes.Buffer
+			stdout := Stdout
+			Stdout = &b
+			defer func() {
+				Stdout = stdout
+			}()
+			opt := v.opt
+			opt.file = in
+			opt.dict = testDictPath
+			opt.mode = "normal"
+			opt.pattern = p
+			if err := command(context.TODO(), &opt); err != nil {
+				t.Fatalf("unexpected error, %v", err)
+			}
+			if got := b.String(); got != v.want {
+				t.Errorf("got %s, want %s", got, v.want)
+			}
+		})
+	}
+}
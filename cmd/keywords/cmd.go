@@ -0,0 +1,268 @@
+package keywords
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome-dict/uni"
+	"github.com/ikawaha/kagome/v2/filter/ja"
+	"github.com/ikawaha/kagome/v2/keyword"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/ikawaha/kagome/v2/userdict"
+)
+
+// subcommand property
+const (
+	CommandName  = "keywords"
+	Description  = `keyword extraction`
+	usageMessage = "%s [-file input_file] [-dict dic_file] [-udict user_dic_file] [-sysdict (ipa|uni)]" +
+		" [-method (tfidf|textrank)] [-idf idf_file] [-build-idf output_file] [-top n] [-window n] [-compound] [-json]" +
+		" [-stop-words stop_words_file] [-stop-tags stop_tags_file]"
+)
+
+var (
+	// Stdout is the standard writer.
+	Stdout io.Writer = os.Stdout
+	// Stderr is the standard error writer.
+	Stderr io.Writer = os.Stderr
+)
+
+// options
+type option struct {
+	file      string
+	dict      string
+	udict     string
+	sysdict   string
+	method    string
+	idf       string
+	buildIDF  string
+	top       int
+	window    int
+	compound  bool
+	json      bool
+	stopWords string
+	stopTags  string
+	flagSet   *flag.FlagSet
+}
+
+// ContinueOnError ErrorHandling // Return a descriptive error.
+// ExitOnError                   // Call os.Exit(2).
+// PanicOnError                  // Call panic with a descriptive error.flag.ContinueOnError
+func newOption(w io.Writer, eh flag.ErrorHandling) (o *option) {
+	o = &option{
+		flagSet: flag.NewFlagSet(CommandName, eh),
+	}
+	// option settings
+	o.flagSet.SetOutput(w)
+	o.flagSet.StringVar(&o.file, "file", "", "input file")
+	o.flagSet.StringVar(&o.dict, "dict", "", "dict")
+	o.flagSet.StringVar(&o.udict, "udict", "", "user dict")
+	o.flagSet.StringVar(&o.sysdict, "sysdict", "ipa", "system dict type (ipa|uni)")
+	o.flagSet.StringVar(&o.method, "method", "tfidf", "scoring method (tfidf|textrank)")
+	o.flagSet.StringVar(&o.idf, "idf", "", "IDF table for tfidf")
+	o.flagSet.StringVar(&o.buildIDF, "build-idf", "", "build an IDF table from the input, one document per line, and write it to the file")
+	o.flagSet.IntVar(&o.top, "top", 10, "number of keywords (0: all)")
+	o.flagSet.IntVar(&o.window, "window", keyword.DefaultWindow, "co-occurrence window size for textrank")
+	o.flagSet.BoolVar(&o.compound, "compound", true, "join a run of nouns into a compound noun")
+	o.flagSet.BoolVar(&o.json, "json", false, "outputs in JSON format")
+	o.flagSet.StringVar(&o.stopWords, "stop-words", "", "stop words file which replaces the default stop words")
+	o.flagSet.StringVar(&o.stopTags, "stop-tags", "", "stop tags file which replaces the default stop tags")
+	return
+}
+
+func (o *option) parse(args []string) error {
+	if err := o.flagSet.Parse(args); err != nil {
+		return err
+	}
+	// validations
+	if nonFlag := o.flagSet.Args(); len(nonFlag) != 0 {
+		return fmt.Errorf("invalid argument: %v", nonFlag)
+	}
+	if o.sysdict != "" && o.sysdict != "ipa" && o.sysdict != "uni" {
+		return fmt.Errorf("invalid argument: -sysdict %v", o.sysdict)
+	}
+	if o.method != "tfidf" && o.method != "textrank" {
+		return fmt.Errorf("invalid argument: -method %v", o.method)
+	}
+	if o.top < 0 {
+		return fmt.Errorf("invalid argument: -top %v", o.top)
+	}
+	if o.window < 2 {
+		return fmt.Errorf("invalid argument: -window %v", o.window)
+	}
+	return nil
+}
+
+// OptionCheck receives a slice of args and returns an error if it was not successfully parsed
+func OptionCheck(args []string) error {
+	opt := newOption(io.Discard, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return nil
+}
+
+func selectDict(path, sysdict string) (*dict.Dict, error) {
+	if path != "" {
+		return dict.LoadDictFile(path)
+	}
+	switch sysdict {
+	case "ipa":
+		return ipa.Dict(), nil
+	case "uni":
+		return uni.Dict(), nil
+	}
+	return nil, fmt.Errorf("unknown dict type, %v", sysdict)
+}
+
+func readIDF(path string) (*keyword.IDF, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return keyword.ReadIDF(f)
+}
+
+func writeIDF(path string, idf *keyword.IDF) error {
+	f, err := os.OpenFile(path, os.O_RDWR|os.O_TRUNC|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := idf.WriteTo(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+func command(_ context.Context, opt *option) error {
+	d, err := selectDict(opt.dict, opt.sysdict)
+	if err != nil {
+		return err
+	}
+	udict := tokenizer.Nop()
+	if opt.udict != "" {
+		d, err := userdict.Load(opt.udict)
+		if err != nil {
+			return err
+		}
+		udict = tokenizer.UserDict(d)
+	}
+	t, err := tokenizer.New(d, udict, tokenizer.OmitBosEos())
+	if err != nil {
+		return err
+	}
+	var opts []ja.FilterOption
+	if opt.stopWords != "" {
+		o, err := ja.StopWordsFilterOptionFromFile(opt.stopWords)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, o)
+	}
+	if opt.stopTags != "" {
+		o, err := ja.StopTagsFilterOptionFromFile(opt.stopTags)
+		if err != nil {
+			return err
+		}
+		opts = append(opts, o)
+	}
+	newFilter := ja.NewFilter
+	if opt.sysdict == "uni" {
+		newFilter = ja.NewUniDicFilter
+	}
+	f, err := newFilter(opts...)
+	if err != nil {
+		return err
+	}
+	e := keyword.NewExtractor(f)
+	e.JoinCompounds = opt.compound
+
+	fp := os.Stdin
+	if opt.file != "" {
+		var err error
+		fp, err = os.Open(opt.file)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = fp.Close()
+		}()
+	}
+	if opt.buildIDF != "" {
+		idf := keyword.NewIDF()
+		s := bufio.NewScanner(fp)
+		for s.Scan() {
+			if strings.TrimSpace(s.Text()) == "" {
+				continue
+			}
+			idf.Add(e.Terms(t.Tokenize(s.Text())))
+		}
+		if err := s.Err(); err != nil {
+			return err
+		}
+		return writeIDF(opt.buildIDF, idf)
+	}
+
+	b, err := io.ReadAll(fp)
+	if err != nil {
+		return err
+	}
+	terms := e.Terms(t.Tokenize(string(b)))
+	var kws []keyword.Keyword
+	switch opt.method {
+	case "textrank":
+		kws = keyword.TextRank(terms, opt.window, opt.top)
+	default:
+		var idf *keyword.IDF
+		if opt.idf != "" {
+			if idf, err = readIDF(opt.idf); err != nil {
+				return err
+			}
+		}
+		kws = keyword.TFIDF(terms, idf, opt.top)
+	}
+	if opt.json {
+		if kws == nil {
+			kws = []keyword.Keyword{}
+		}
+		return json.NewEncoder(Stdout).Encode(kws)
+	}
+	w := bufio.NewWriter(Stdout)
+	defer w.Flush()
+	for _, v := range kws {
+		fmt.Fprintf(w, "%s\t%.4f\n", v.Word, v.Score)
+	}
+	return nil
+}
+
+// Run receives the slice of args and executes the keywords tool
+func Run(ctx context.Context, args []string) error {
+	opt := newOption(Stderr, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		Usage()
+		PrintDefaults(flag.ContinueOnError)
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return command(ctx, opt)
+}
+
+// Usage provides information on the use of the keywords tool
+func Usage() {
+	fmt.Fprintf(Stderr, usageMessage+"\n", CommandName)
+}
+
+// PrintDefaults prints out the default flags
+func PrintDefaults(eh flag.ErrorHandling) {
+	o := newOption(Stderr, eh)
+	o.flagSet.PrintDefaults()
+}
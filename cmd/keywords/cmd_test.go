@@ -0,0 +1,139 @@
+package keywords
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testDictPath = "../../testdata/ipa.dict"
+
+func TestOptionCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name:    "no options",
+			args:    []string{},
+			wantErr: false,
+		},
+		{
+			name:    "non flag options",
+			args:    []string{"piyo"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid method",
+			args:    []string{"-method", "piyo"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid top",
+			args:    []string{"-top", "-1"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid window",
+			args:    []string{"-window", "1"},
+			wantErr: true,
+		},
+		{
+			name: "all options",
+			args: []string{
+				"-file", "input.txt",
+				"-dict", testDictPath,
+				"-udict", "../../testdata/userdict.txt",
+				"-sysdict", "uni",
+				"-method", "textrank",
+				"-idf", "idf.tsv",
+				"-build-idf", "out.tsv",
+				"-top", "5",
+				"-window", "3",
+				"-compound=false",
+				"-json",
+				"-stop-words", "stop_words.txt",
+				"-stop-tags", "stop_tags.txt",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := OptionCheck(tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("OptionCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+	var b bytes.Buffer
+	stdout := Stdout
+	Stdout = &b
+	defer func() {
+		Stdout = stdout
+	}()
+
+	dir := t.TempDir()
+	in := filepath.Join(dir, "input.txt")
+	if err := os.WriteFile(in, []byte("関西国際空港から東京都庁まで電車で移動し、関西国際空港に戻った。\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	t.Run("tfidf", func(t *testing.T) {
+		b.Reset()
+		if err := command(context.TODO(), &option{
+			file:     in,
+			dict:     testDictPath,
+			method:   "tfidf",
+			top:      2,
+			window:   2,
+			compound: true,
+		}); err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		if got, want := b.String(), "関西国際空港\t0.3333\n戻る\t0.1667\n"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+	t.Run("textrank json", func(t *testing.T) {
+		b.Reset()
+		if err := command(context.TODO(), &option{
+			file:     in,
+			dict:     testDictPath,
+			method:   "textrank",
+			top:      1,
+			window:   2,
+			compound: true,
+			json:     true,
+		}); err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		if got, want := b.String(), `[{"word":"関西国際空港","score":`; !strings.HasPrefix(got, want) {
+			t.Errorf("got %q, want prefix %q", got, want)
+		}
+	})
+	t.Run("build idf", func(t *testing.T) {
+		out := filepath.Join(dir, "idf.tsv")
+		if err := command(context.TODO(), &option{
+			file:     in,
+			dict:     testDictPath,
+			buildIDF: out,
+			compound: true,
+		}); err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		got, err := os.ReadFile(out)
+		if err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		want := "#documents\t1\n戻る\t1\n東京都庁\t1\n移動\t1\n関西国際空港\t1\n電車\t1\n"
+		if string(got) != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+}
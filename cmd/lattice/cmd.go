@@ -13,6 +13,7 @@ import (
 	"github.com/ikawaha/kagome-dict/ipa"
 	"github.com/ikawaha/kagome-dict/uni"
 	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/ikawaha/kagome/v2/userdict"
 )
 
 // subcommand property
@@ -109,7 +110,7 @@ func command(_ context.Context, opt *option) error {
 	}
 	udict := tokenizer.Nop()
 	if opt.udict != "" {
-		d, err := dict.NewUserDict(opt.udict)
+		d, err := userdict.Load(opt.udict)
 		if err != nil {
 			return err
 		}
@@ -0,0 +1,204 @@
+package lookup
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome-dict/uni"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/ikawaha/kagome/v2/userdict"
+)
+
+// subcommand property
+const (
+	CommandName  = "lookup"
+	Description  = `dictionary lookup`
+	usageMessage = "%s [-dict dic_file] [-udict user_dic_file] [-sysdict (ipa|uni)]" +
+		" [-search (exact|common-prefix|predictive)] [-limit n] [-json] word..."
+)
+
+var (
+	// Stdout is the standard writer.
+	Stdout io.Writer = os.Stdout
+	// Stderr is the standard error writer.
+	Stderr io.Writer = os.Stderr
+)
+
+// options
+type option struct {
+	dict    string
+	udict   string
+	sysdict string
+	search  string
+	limit   int
+	json    bool
+	words   []string
+	flagSet *flag.FlagSet
+}
+
+// ContinueOnError ErrorHandling // Return a descriptive error.
+// ExitOnError                   // Call os.Exit(2).
+// PanicOnError                  // Call panic with a descriptive error.flag.ContinueOnError
+func newOption(w io.Writer, eh flag.ErrorHandling) (o *option) {
+	o = &option{
+		flagSet: flag.NewFlagSet(CommandName, eh),
+	}
+	// option settings
+	o.flagSet.SetOutput(w)
+	o.flagSet.StringVar(&o.dict, "dict", "", "dict")
+	o.flagSet.StringVar(&o.udict, "udict", "", "user dict")
+	o.flagSet.StringVar(&o.sysdict, "sysdict", "ipa", "system dict type (ipa|uni)")
+	o.flagSet.StringVar(&o.search, "search", "exact", "search type (exact|common-prefix|predictive)")
+	o.flagSet.IntVar(&o.limit, "limit", 0, "maximum number of entries per word (0: unlimited)")
+	o.flagSet.BoolVar(&o.json, "json", false, "outputs in JSON format")
+	return
+}
+
+func (o *option) parse(args []string) error {
+	if err := o.flagSet.Parse(args); err != nil {
+		return err
+	}
+	// validations
+	if o.flagSet.NArg() == 0 {
+		return errors.New("no word is specified")
+	}
+	if o.sysdict != "" && o.sysdict != "ipa" && o.sysdict != "uni" {
+		return fmt.Errorf("invalid argument: -sysdict %v", o.sysdict)
+	}
+	if o.search != "" && o.search != "exact" && o.search != "common-prefix" && o.search != "predictive" {
+		return fmt.Errorf("invalid argument: -search %v", o.search)
+	}
+	if o.limit < 0 {
+		return fmt.Errorf("invalid argument: -limit %v", o.limit)
+	}
+	o.words = o.flagSet.Args()
+	return nil
+}
+
+// OptionCheck receives a slice of args and returns an error if it was not successfully parsed
+func OptionCheck(args []string) error {
+	opt := newOption(io.Discard, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return nil
+}
+
+func selectDict(path, sysdict string) (*dict.Dict, error) {
+	if path != "" {
+		return dict.LoadDictFile(path)
+	}
+	switch sysdict {
+	case "ipa":
+		return ipa.Dict(), nil
+	case "uni":
+		return uni.Dict(), nil
+	}
+	return nil, fmt.Errorf("unknown dict type, %v", sysdict)
+}
+
+func selectSearch(search string) tokenizer.LookupMode {
+	switch search {
+	case "exact":
+		return tokenizer.Exact
+	case "common-prefix":
+		return tokenizer.CommonPrefix
+	case "predictive":
+		return tokenizer.Predictive
+	}
+	return tokenizer.Exact
+}
+
+// entry is the JSON output format of a dictionary entry.
+type entry struct {
+	ID       int      `json:"id"`
+	Surface  string   `json:"surface"`
+	Class    string   `json:"class"`
+	LeftID   int      `json:"left_id"`
+	RightID  int      `json:"right_id"`
+	Cost     int      `json:"cost"`
+	Features []string `json:"features"`
+}
+
+func command(_ context.Context, opt *option) error {
+	d, err := selectDict(opt.dict, opt.sysdict)
+	if err != nil {
+		return err
+	}
+	udict := tokenizer.Nop()
+	if opt.udict != "" {
+		d, err := userdict.Load(opt.udict)
+		if err != nil {
+			return err
+		}
+		udict = tokenizer.UserDict(d)
+	}
+	t, err := tokenizer.New(d, udict)
+	if err != nil {
+		return err
+	}
+	w := bufio.NewWriter(Stdout)
+	defer w.Flush()
+	mode := selectSearch(opt.search)
+	for _, word := range opt.words {
+		entries := t.Lookup(word, mode, opt.limit)
+		if opt.json {
+			list := make([]entry, 0, len(entries))
+			for _, v := range entries {
+				list = append(list, entry{
+					ID:       v.ID,
+					Surface:  v.Surface,
+					Class:    v.Class.String(),
+					LeftID:   v.LeftID,
+					RightID:  v.RightID,
+					Cost:     v.Cost,
+					Features: v.Features(),
+				})
+			}
+			b, err := json.Marshal(list)
+			if err != nil {
+				return err
+			}
+			w.Write(b)
+			w.WriteString("\n")
+			continue
+		}
+		for _, v := range entries {
+			fmt.Fprintf(w, "%s\t%v\t%d\t%d\t%d\t%s\n",
+				v.Surface, v.Class, v.LeftID, v.RightID, v.Cost, strings.Join(v.Features(), ","))
+		}
+		w.WriteString("EOS\n")
+	}
+	return nil
+}
+
+// Run receives the slice of args and executes the lookup tool
+func Run(ctx context.Context, args []string) error {
+	opt := newOption(Stderr, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		Usage()
+		PrintDefaults(flag.ContinueOnError)
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return command(ctx, opt)
+}
+
+// Usage provides information on the use of the lookup tool
+func Usage() {
+	fmt.Fprintf(Stderr, usageMessage+"\n", CommandName)
+}
+
+// PrintDefaults prints out the default flags
+func PrintDefaults(eh flag.ErrorHandling) {
+	o := newOption(Stderr, eh)
+	o.flagSet.PrintDefaults()
+}
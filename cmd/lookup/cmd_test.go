@@ -0,0 +1,125 @@
+package lookup
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+const (
+	testDictPath     = "../../testdata/ipa.dict"
+	testUserDictPath = "../../testdata/userdict.txt"
+)
+
+func TestOptionCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name:    "empty args",
+			args:    []string{},
+			wantErr: true,
+		},
+		{
+			name:    "word only",
+			args:    []string{"ねこ"},
+			wantErr: false,
+		},
+		{
+			name:    "invalid sysdict",
+			args:    []string{"-sysdict", "piyo", "ねこ"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid search",
+			args:    []string{"-search", "piyo", "ねこ"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid limit",
+			args:    []string{"-limit", "-1", "ねこ"},
+			wantErr: true,
+		},
+		{
+			name: "all options and words",
+			args: []string{
+				"-dict", testDictPath,
+				"-udict", testUserDictPath,
+				"-sysdict", "uni",
+				"-search", "predictive",
+				"-limit", "10",
+				"-json",
+				"ねこ", "いぬ",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := OptionCheck(tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("OptionCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+	var b bytes.Buffer
+	stdout := Stdout
+	Stdout = &b
+	defer func() {
+		Stdout = stdout
+	}()
+
+	t.Run("text output", func(t *testing.T) {
+		b.Reset()
+		if err := command(context.TODO(), &option{
+			dict:   testDictPath,
+			udict:  testUserDictPath,
+			search: "common-prefix",
+			words:  []string{"朝青龍です"},
+		}); err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		lines := strings.Split(strings.TrimSuffix(b.String(), "\n"), "\n")
+		if got, want := lines[0], "朝青龍\tUSER\t0\t0\t0\t"+"カスタム人名,朝青龍,アサショウリュウ"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+		if got, want := lines[len(lines)-1], "EOS"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+		for _, v := range lines[1 : len(lines)-1] {
+			if cols := strings.Split(v, "\t"); len(cols) != 6 || cols[1] != "KNOWN" {
+				t.Errorf("unexpected line, %q", v)
+			}
+		}
+	})
+	t.Run("json output", func(t *testing.T) {
+		b.Reset()
+		if err := command(context.TODO(), &option{
+			dict:   testDictPath,
+			search: "predictive",
+			limit:  2,
+			json:   true,
+			words:  []string{"ねこ"},
+		}); err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		var got []entry
+		if err := json.Unmarshal(b.Bytes(), &got); err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("got %+v, want 2 entries", got)
+		}
+		for _, v := range got {
+			if !strings.HasPrefix(v.Surface, "ねこ") || v.Class != "KNOWN" || len(v.Features) == 0 {
+				t.Errorf("unexpected entry, %+v", v)
+			}
+		}
+	})
+}
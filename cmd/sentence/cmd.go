@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
+	"unicode"
 
 	"github.com/ikawaha/kagome/v2/filter"
 )
@@ -15,7 +17,7 @@ import (
 const (
 	CommandName  = "sentence"
 	Description  = `tiny sentence splitter`
-	usageMessage = "%s [-file filename]"
+	usageMessage = "%s [-file filename] [-span]"
 )
 
 // Stderr writes to stderr
@@ -27,6 +29,7 @@ var (
 // options
 type option struct {
 	file    string
+	span    bool
 	flagSet *flag.FlagSet
 }
 
@@ -40,6 +43,7 @@ func newOption(w io.Writer, eh flag.ErrorHandling) (o *option) {
 	// option settings
 	o.flagSet.SetOutput(w)
 	o.flagSet.StringVar(&o.file, "file", "", "input file")
+	o.flagSet.BoolVar(&o.span, "span", false, "prefix each sentence with the byte and rune offsets of its span in the input")
 	return
 }
 
@@ -75,6 +79,9 @@ func command(_ context.Context, w io.Writer, opt *option) error {
 			_ = fp.Close()
 		}()
 	}
+	if opt.span {
+		return printSpans(w, fp)
+	}
 	scanner := bufio.NewScanner(fp)
 	scanner.Split(filter.ScanSentences)
 	for scanner.Scan() {
@@ -83,6 +90,26 @@ func command(_ context.Context, w io.Writer, opt *option) error {
 	return scanner.Err()
 }
 
+// printSpans prints the sentences with their spans, the byte start, byte end,
+// rune start and rune end, separated by tabs.
+func printSpans(w io.Writer, r io.Reader) error {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	input := string(b)
+	for _, v := range filter.SentenceSpans(input) {
+		sentence := strings.Map(func(r rune) rune {
+			if unicode.IsSpace(r) {
+				return -1
+			}
+			return r
+		}, input[v.Start:v.End])
+		fmt.Fprintf(w, "%d\t%d\t%d\t%d\t%s\n", v.Start, v.End, v.RuneStart, v.RuneEnd, sentence)
+	}
+	return nil
+}
+
 // Run receives the slice of args and executes the tokenize tool
 func Run(ctx context.Context, args []string) error {
 	opt := newOption(io.Discard, flag.ContinueOnError)
@@ -87,7 +87,7 @@ func TestUsage(t *testing.T) {
 		Stderr = os.Stderr
 	}()
 	Usage()
-	want := `sentence [-file filename]` + "\n"
+	want := `sentence [-file filename] [-span]` + "\n"
 	if got := b.String(); got != want {
 		t.Errorf("got %q, want %q", got, want)
 	}
@@ -117,6 +117,16 @@ func Test_command(t *testing.T) {
 			want:    "吾輩は猫である。\n名前はまだ無い。\n",
 			wantErr: false,
 		},
+		{
+			name: "span",
+			args: &option{
+				file:    "../../testdata/nekodearu.txt",
+				span:    true,
+				flagSet: flag.NewFlagSet(CommandName, flag.ContinueOnError),
+			},
+			want:    "0\t24\t0\t8\t吾輩は猫である。\n24\t48\t8\t16\t名前はまだ無い。\n",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
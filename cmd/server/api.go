@@ -36,24 +36,9 @@ func (h *TokenizeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		w.Write([]byte(`{"status":true,"tokens":[]}`))
 		return
 	}
-	mode := tokenizer.Normal
-	switch req.Mode {
-	case "Search":
-		mode = tokenizer.Search
-	case "Extended":
-		mode = tokenizer.Extended
-	}
-	tokens := h.tokenizer.Analyze(req.Input, mode)
-	var tokenData []tokenizer.TokenData
-	for _, v := range tokens {
-		if v.ID == tokenizer.BosEosID {
-			continue
-		}
-		tokenData = append(tokenData, tokenizer.NewTokenData(v))
-	}
 	resp, err := json.Marshal(TokenizerResponseBody{
 		Status: true,
-		Tokens: tokenData,
+		Tokens: analyze(h.tokenizer, req.Input, selectMode(req.Mode)),
 	})
 	if err != nil {
 		http.Error(w, fmt.Sprintf("{\"status\":false,\"error\":\"%v\"}", err), http.StatusInternalServerError)
@@ -62,3 +47,26 @@ func (h *TokenizeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	w.WriteHeader(http.StatusOK)
 	w.Write(resp)
 }
+
+func selectMode(mode string) tokenizer.TokenizeMode {
+	switch mode {
+	case "Search":
+		return tokenizer.Search
+	case "Extended":
+		return tokenizer.Extended
+	}
+	return tokenizer.Normal
+}
+
+// analyze tokenizes the input and returns the token data without BOS/EOS.
+func analyze(t *tokenizer.Tokenizer, input string, mode tokenizer.TokenizeMode) []tokenizer.TokenData {
+	tokens := t.Analyze(input, mode)
+	ret := make([]tokenizer.TokenData, 0, len(tokens))
+	for _, v := range tokens {
+		if v.ID == tokenizer.BosEosID {
+			continue
+		}
+		ret = append(ret, tokenizer.NewTokenData(v))
+	}
+	return ret
+}
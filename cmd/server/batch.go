@@ -0,0 +1,97 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// maxBatchSize is the maximum number of the items of a batch request.
+const maxBatchSize = 10000
+
+// BatchTokenizeHandler represents the batch tokenizer API server struct.
+// The items of the requests are tokenized concurrently by the workers which
+// are shared among the requests.
+type BatchTokenizeHandler struct {
+	tokenizer *tokenizer.Tokenizer
+	workers   chan struct{} // semaphore of the workers
+}
+
+// NewBatchTokenizeHandler returns a batch tokenizer API handler which runs
+// at most n workers at a time.
+func NewBatchTokenizeHandler(t *tokenizer.Tokenizer, n int) *BatchTokenizeHandler {
+	if n < 1 {
+		n = 1
+	}
+	return &BatchTokenizeHandler{
+		tokenizer: t,
+		workers:   make(chan struct{}, n),
+	}
+}
+
+// BatchTokenizerRequestBody is the type of the "tokenize/batch" endpoint HTTP request body.
+// The mode of an item overrides the mode of the batch.
+type BatchTokenizerRequestBody struct {
+	Mode  string                 `json:"mode,omitempty"`
+	Items []TokenizerRequestBody `json:"items"`
+}
+
+// BatchTokenizerResponseBody is the response type of the "tokenize/batch" endpoint.
+// The results are in the same order as the items of the request.
+type BatchTokenizerResponseBody struct {
+	Status  bool                    `json:"status"`
+	Results []TokenizerResponseBody `json:"results"`
+}
+
+func (h *BatchTokenizeHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	var req BatchTokenizerRequestBody
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, fmt.Sprintf("{\"status\":false,\"error\":\"%v\"}", err), http.StatusBadRequest)
+		return
+	}
+	if len(req.Items) > maxBatchSize {
+		http.Error(w, fmt.Sprintf("{\"status\":false,\"error\":\"too many items, %d > %d\"}", len(req.Items), maxBatchSize), http.StatusBadRequest)
+		return
+	}
+	results := make([]TokenizerResponseBody, len(req.Items))
+	var wg sync.WaitGroup
+	for i, v := range req.Items {
+		select {
+		case h.workers <- struct{}{}:
+		case <-r.Context().Done():
+			wg.Wait()
+			http.Error(w, fmt.Sprintf("{\"status\":false,\"error\":\"%v\"}", r.Context().Err()), http.StatusServiceUnavailable)
+			return
+		}
+		mode := v.Mode
+		if mode == "" {
+			mode = req.Mode
+		}
+		wg.Add(1)
+		go func(i int, input string, mode tokenizer.TokenizeMode) {
+			defer func() {
+				<-h.workers
+				wg.Done()
+			}()
+			results[i] = TokenizerResponseBody{
+				Status: true,
+				Tokens: analyze(h.tokenizer, input, mode),
+			}
+		}(i, v.Input, selectMode(mode))
+	}
+	wg.Wait()
+	resp, err := json.Marshal(BatchTokenizerResponseBody{
+		Status:  true,
+		Results: results,
+	})
+	if err != nil {
+		http.Error(w, fmt.Sprintf("{\"status\":false,\"error\":\"%v\"}", err), http.StatusInternalServerError)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+	w.Write(resp)
+}
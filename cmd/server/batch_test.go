@@ -0,0 +1,117 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+func TestBatchTokenizerAPI(t *testing.T) {
+	tnz, err := tokenizer.New(loadTestDict(t))
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	h := NewBatchTokenizeHandler(tnz, 2)
+
+	t.Run("empty items", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/tokenize/batch", strings.NewReader(`{"items":[]}`))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if got, want := resp.StatusCode, http.StatusOK; got != want {
+			t.Errorf("http status code got %d(%s), want %d", got, resp.Status, want)
+		}
+		b, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatalf("unexpected response body error, %v", err)
+		}
+		if got, want := string(b), `{"status":true,"results":[]}`; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("invalid request", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/tokenize/batch", strings.NewReader(`{"items":`))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if got, want := w.Result().StatusCode, http.StatusBadRequest; got != want {
+			t.Errorf("http status code got %d, want %d", got, want)
+		}
+	})
+
+	t.Run("too many items", func(t *testing.T) {
+		p, err := json.Marshal(BatchTokenizerRequestBody{
+			Items: make([]TokenizerRequestBody, maxBatchSize+1),
+		})
+		if err != nil {
+			t.Fatalf("unexpected json marshal error, %v", err)
+		}
+		req := httptest.NewRequest(http.MethodPost, "/tokenize/batch", bytes.NewReader(p))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if got, want := w.Result().StatusCode, http.StatusBadRequest; got != want {
+			t.Errorf("http status code got %d, want %d", got, want)
+		}
+	})
+
+	t.Run("results in order", func(t *testing.T) {
+		body := BatchTokenizerRequestBody{Mode: "Extended"}
+		var want [][]string
+		for i := 0; i < 100; i++ {
+			item := TokenizerRequestBody{Input: fmt.Sprintf("ポポピ%dねこです", i)}
+			mode := tokenizer.Extended
+			switch i % 3 {
+			case 1:
+				item.Mode, mode = "Normal", tokenizer.Normal
+			case 2:
+				item.Input = ""
+			}
+			body.Items = append(body.Items, item)
+			surfaces := []string{}
+			for _, v := range tnz.Analyze(item.Input, mode) {
+				if v.ID != tokenizer.BosEosID {
+					surfaces = append(surfaces, v.Surface)
+				}
+			}
+			want = append(want, surfaces)
+		}
+		p, err := json.Marshal(body)
+		if err != nil {
+			t.Fatalf("unexpected json marshal error, %v", err)
+		}
+		req := httptest.NewRequest(http.MethodPost, "/tokenize/batch", bytes.NewReader(p))
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if got, want := resp.StatusCode, http.StatusOK; got != want {
+			t.Errorf("http status code got %d(%s), want %d", got, resp.Status, want)
+		}
+		var got BatchTokenizerResponseBody
+		if err := json.NewDecoder(resp.Body).Decode(&got); err != nil {
+			t.Fatalf("unexpected json unmarshal error, %v", err)
+		}
+		if !got.Status || len(got.Results) != len(want) {
+			t.Fatalf("got %+v, want %d results", got, len(want))
+		}
+		for i, v := range got.Results {
+			surfaces := []string{}
+			for _, tok := range v.Tokens {
+				surfaces = append(surfaces, tok.Surface)
+			}
+			if fmt.Sprint(surfaces) != fmt.Sprint(want[i]) {
+				t.Errorf("result %d: got %v, want %v", i, surfaces, want[i])
+			}
+		}
+	})
+}
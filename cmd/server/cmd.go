@@ -9,6 +9,7 @@ import (
 	"net/http"
 	"os"
 	"os/signal"
+	"runtime"
 	"syscall"
 	"time"
 
@@ -16,6 +17,7 @@ import (
 	"github.com/ikawaha/kagome-dict/ipa"
 	"github.com/ikawaha/kagome-dict/uni"
 	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/ikawaha/kagome/v2/userdict"
 )
 
 // Stderr is the standard error writer.
@@ -25,7 +27,7 @@ var Stderr io.Writer = os.Stderr
 var (
 	CommandName  = "server"
 	Description  = `run tokenize server`
-	usageMessage = "%s [-http=:6060] [-userdict userdic_file] [-dict (ipa|uni)]"
+	usageMessage = "%s [-http=:6060] [-userdict userdic_file] [-dict (ipa|uni)] [-workers n]"
 )
 
 // options
@@ -33,6 +35,7 @@ type option struct {
 	http    string
 	dict    string
 	udict   string
+	workers int
 	flagSet *flag.FlagSet
 }
 
@@ -48,6 +51,7 @@ func newOption(w io.Writer, eh flag.ErrorHandling) *option {
 	ret.flagSet.StringVar(&ret.http, "http", ":6060", "HTTP service address")
 	ret.flagSet.StringVar(&ret.udict, "userdict", "", "user dict")
 	ret.flagSet.StringVar(&ret.dict, "dict", "ipa", "system dict type (ipa|uni)")
+	ret.flagSet.IntVar(&ret.workers, "workers", runtime.NumCPU(), "number of workers of the batch endpoint")
 	return ret
 }
 
@@ -62,6 +66,9 @@ func (o *option) parse(args []string) error {
 	if o.dict != "" && o.dict != "ipa" && o.dict != "uni" {
 		return fmt.Errorf("invalid argument: -dict %v", o.dict)
 	}
+	if o.workers < 1 {
+		return fmt.Errorf("invalid argument: -workers %v", o.workers)
+	}
 	return nil
 }
 
@@ -91,7 +98,7 @@ func command(ctx context.Context, opt *option) error {
 	}
 	udict := tokenizer.Nop()
 	if opt.udict != "" {
-		d, err := dict.NewUserDict(opt.udict)
+		d, err := userdict.Load(opt.udict)
 		if err != nil {
 			return err
 		}
@@ -105,6 +112,8 @@ func command(ctx context.Context, opt *option) error {
 	mux := http.NewServeMux()
 	mux.Handle("/", &TokenizeDemoHandler{tokenizer: t})
 	mux.Handle("/tokenize", &TokenizeHandler{tokenizer: t})
+	mux.Handle("/tokenize/batch", NewBatchTokenizeHandler(t, opt.workers))
+	mux.Handle("/tokenize/stream", &TokenizeStreamHandler{tokenizer: t})
 	srv := http.Server{
 		Addr:              opt.http,
 		Handler:           mux,
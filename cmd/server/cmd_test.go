@@ -30,12 +30,18 @@ func TestOptionCheck(t *testing.T) {
 			args:    []string{"-dict", "piyo"},
 			wantErr: true,
 		},
+		{
+			name:    "invalid workers",
+			args:    []string{"-workers", "0"},
+			wantErr: true,
+		},
 		{
 			name: "all args",
 			args: []string{
 				"-userdict", "../../testdata/userdict.txt",
 				"-http", ":8888",
 				"-dict", "ipa",
+				"-workers", "4",
 			},
 			wantErr: false,
 		},
@@ -103,7 +109,7 @@ func TestUsage(t *testing.T) {
 		Stderr = os.Stderr
 	}()
 	Usage()
-	want := `server [-http=:6060] [-userdict userdic_file] [-dict (ipa|uni)]` + "\n"
+	want := `server [-http=:6060] [-userdict userdic_file] [-dict (ipa|uni)] [-workers n]` + "\n"
 	if got := b.String(); got != want {
 		t.Errorf("got %q, want %q", got, want)
 	}
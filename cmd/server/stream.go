@@ -0,0 +1,136 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// maxStreamSentenceSize is the maximum byte size of a sentence of the stream.
+const maxStreamSentenceSize = 1024 * 1024
+
+// TokenizeStreamHandler represents the streaming tokenizer API server struct.
+//
+// The request body is a plain text, or NDJSON of the "tokenize" endpoint
+// request bodies if the content type is application/x-ndjson. The text is
+// split into sentences and the results are streamed back as NDJSON while
+// reading the request. The mode of the text is given by the "mode" query
+// parameter, which is also the default mode of the documents of NDJSON.
+//
+// Streaming the results while reading the request over HTTP/1.x requires
+// Go 1.21 or later, where the handler enables full duplex. With earlier
+// versions, the server may be unable to read the rest of the request body
+// after the first result is written, so the request should be small enough
+// to be read before the response, or be sent over HTTP/2.
+type TokenizeStreamHandler struct {
+	tokenizer *tokenizer.Tokenizer
+}
+
+// TokenizerStreamResponseLine is a line of the "tokenize/stream" endpoint response.
+type TokenizerStreamResponseLine struct {
+	Document int                   `json:"document"` // index of the document of NDJSON
+	Index    int                   `json:"index"`    // index of the sentence in the document
+	Sentence string                `json:"sentence"`
+	Tokens   []tokenizer.TokenData `json:"tokens"`
+}
+
+// streamWriter writes the lines of the response and flushes them.
+type streamWriter struct {
+	w       http.ResponseWriter
+	enc     *json.Encoder
+	written bool
+}
+
+func newStreamWriter(w http.ResponseWriter) *streamWriter {
+	enc := json.NewEncoder(w)
+	enc.SetEscapeHTML(false)
+	return &streamWriter{w: w, enc: enc}
+}
+
+func (s *streamWriter) write(v any) error {
+	s.written = true
+	if err := s.enc.Encode(v); err != nil {
+		return err
+	}
+	if f, ok := s.w.(http.Flusher); ok {
+		f.Flush()
+	}
+	return nil
+}
+
+// error reports the error with the status code, or with a line of the
+// response if some lines have already been written.
+func (s *streamWriter) error(err error, code int) {
+	if s.written {
+		_ = s.enc.Encode(map[string]any{"status": false, "error": err.Error()})
+		return
+	}
+	http.Error(s.w, fmt.Sprintf("{\"status\":false,\"error\":\"%v\"}", err), code)
+}
+
+func (h *TokenizeStreamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// HTTP/1.x server cannot read the request body after writing the
+	// response unless full duplex is enabled (Go 1.21 or later).
+	if fd, ok := w.(interface{ EnableFullDuplex() error }); ok {
+		_ = fd.EnableFullDuplex()
+	}
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	s := newStreamWriter(w)
+	mode := r.URL.Query().Get("mode")
+	if ct, _, _ := mime.ParseMediaType(r.Header.Get("Content-Type")); ct != "application/x-ndjson" {
+		if err := h.tokenize(s, r.Body, 0, selectMode(mode)); err != nil {
+			s.error(err, http.StatusBadRequest)
+		}
+		return
+	}
+	dec := json.NewDecoder(r.Body)
+	for i := 0; ; i++ {
+		var req TokenizerRequestBody
+		if err := dec.Decode(&req); err != nil {
+			if !errors.Is(err, io.EOF) {
+				s.error(err, http.StatusBadRequest)
+			}
+			return
+		}
+		m := req.Mode
+		if m == "" {
+			m = mode
+		}
+		if err := h.tokenize(s, strings.NewReader(req.Input), i, selectMode(m)); err != nil {
+			s.error(err, http.StatusBadRequest)
+			return
+		}
+	}
+}
+
+// tokenize splits the text of the document into sentences and writes the
+// results of them.
+func (h *TokenizeStreamHandler) tokenize(s *streamWriter, r io.Reader, doc int, mode tokenizer.TokenizeMode) error {
+	sc := bufio.NewScanner(r)
+	sc.Buffer(nil, maxStreamSentenceSize)
+	sc.Split(filter.ScanSentences)
+	for i := 0; sc.Scan(); {
+		sentence := sc.Text()
+		if sentence == "" {
+			continue
+		}
+		if err := s.write(TokenizerStreamResponseLine{
+			Document: doc,
+			Index:    i,
+			Sentence: sentence,
+			Tokens:   analyze(h.tokenizer, sentence, mode),
+		}); err != nil {
+			return err
+		}
+		i++
+	}
+	return sc.Err()
+}
@@ -0,0 +1,71 @@
+//go:build go1.21
+
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// The HTTP/1.x server can read the request body after writing the response
+// only if full duplex is enabled, which requires Go 1.21 or later.
+func TestTokenizerStreamAPI_StreamWhileReading(t *testing.T) {
+	tnz, err := tokenizer.New(loadTestDict(t))
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	h := &TokenizeStreamHandler{tokenizer: tnz}
+	srv := httptest.NewServer(h)
+	defer srv.Close()
+	pr, pw := io.Pipe()
+	req, err := http.NewRequest(http.MethodPost, srv.URL, pr)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	respCh := make(chan *http.Response)
+	go func() {
+		resp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Errorf("unexpected error, %v", err)
+			close(respCh)
+			return
+		}
+		respCh <- resp
+	}()
+	if _, err := io.WriteString(pw, "ねこです。ねこは"); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	resp, ok := <-respCh
+	if !ok {
+		return
+	}
+	defer resp.Body.Close()
+	r := bufio.NewReader(resp.Body)
+	// the first result arrives before the request body ends.
+	line, err := r.ReadBytes('\n')
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	var first TokenizerStreamResponseLine
+	if err := json.Unmarshal(line, &first); err != nil {
+		t.Fatalf("unexpected json unmarshal error, %v, %s", err, line)
+	}
+	if got, want := first.Sentence, "ねこです。"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if _, err := io.WriteString(pw, "います"); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	pw.Close()
+	want := []sentence{{Document: 0, Index: 1, Sentence: "ねこはいます", Tokens: 4}}
+	if got := sentences(readStreamLines(t, r)); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
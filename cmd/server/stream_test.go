@@ -0,0 +1,124 @@
+package server
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+func readStreamLines(t *testing.T, r io.Reader) []TokenizerStreamResponseLine {
+	t.Helper()
+	var ret []TokenizerStreamResponseLine
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		var line TokenizerStreamResponseLine
+		if err := json.Unmarshal(s.Bytes(), &line); err != nil {
+			t.Fatalf("unexpected json unmarshal error, %v, %s", err, s.Text())
+		}
+		ret = append(ret, line)
+	}
+	return ret
+}
+
+type sentence struct {
+	Document int
+	Index    int
+	Sentence string
+	Tokens   int
+}
+
+func sentences(lines []TokenizerStreamResponseLine) []sentence {
+	var ret []sentence
+	for _, v := range lines {
+		ret = append(ret, sentence{Document: v.Document, Index: v.Index, Sentence: v.Sentence, Tokens: len(v.Tokens)})
+	}
+	return ret
+}
+
+func TestTokenizerStreamAPI(t *testing.T) {
+	tnz, err := tokenizer.New(loadTestDict(t))
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	h := &TokenizeStreamHandler{tokenizer: tnz}
+
+	t.Run("text", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/tokenize/stream?mode=Extended", strings.NewReader("ねこです。\n\nポポピ！ねこはいます"))
+		req.Header.Set("Content-Type", "text/plain; charset=utf-8")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if got, want := resp.StatusCode, http.StatusOK; got != want {
+			t.Errorf("http status code got %d(%s), want %d", got, resp.Status, want)
+		}
+		if got, want := resp.Header.Get("Content-Type"), "application/x-ndjson"; got != want {
+			t.Errorf("content type got %q, want %q", got, want)
+		}
+		want := []sentence{
+			{Document: 0, Index: 0, Sentence: "ねこです。", Tokens: 3},
+			{Document: 0, Index: 1, Sentence: "ポポピ！", Tokens: 4},
+			{Document: 0, Index: 2, Sentence: "ねこはいます", Tokens: 4},
+		}
+		if got := sentences(readStreamLines(t, resp.Body)); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("ndjson", func(t *testing.T) {
+		body := `{"sentence":"ねこです。ねこはいます。"}
+{"sentence":"ポポピ", "mode":"Extended"}
+{"sentence":""}
+{"sentence":"ポポピ"}
+`
+		req := httptest.NewRequest(http.MethodPost, "/tokenize/stream", strings.NewReader(body))
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+
+		resp := w.Result()
+		defer resp.Body.Close()
+		if got, want := resp.StatusCode, http.StatusOK; got != want {
+			t.Errorf("http status code got %d(%s), want %d", got, resp.Status, want)
+		}
+		want := []sentence{
+			{Document: 0, Index: 0, Sentence: "ねこです。", Tokens: 3},
+			{Document: 0, Index: 1, Sentence: "ねこはいます。", Tokens: 5},
+			{Document: 1, Index: 0, Sentence: "ポポピ", Tokens: 3},
+			{Document: 3, Index: 0, Sentence: "ポポピ", Tokens: 1},
+		}
+		if got := sentences(readStreamLines(t, resp.Body)); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+
+	t.Run("invalid ndjson", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/tokenize/stream", strings.NewReader(`{"sentence":`))
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		if got, want := w.Result().StatusCode, http.StatusBadRequest; got != want {
+			t.Errorf("http status code got %d, want %d", got, want)
+		}
+	})
+
+	t.Run("invalid ndjson after some documents", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, "/tokenize/stream", strings.NewReader(`{"sentence":"ねこ"} {"sentence":`))
+		req.Header.Set("Content-Type", "application/x-ndjson")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, req)
+		lines := strings.Split(strings.TrimSpace(w.Body.String()), "\n")
+		if len(lines) != 2 || !strings.HasPrefix(lines[1], `{"error":`) {
+			t.Errorf("got %q, want a result and an error", lines)
+		}
+	})
+
+}
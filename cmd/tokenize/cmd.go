@@ -16,7 +16,9 @@ import (
 	"github.com/ikawaha/kagome-dict/ipa"
 	"github.com/ikawaha/kagome-dict/uni"
 	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/filter/ja"
 	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/ikawaha/kagome/v2/userdict"
 )
 
 // subcommand property
@@ -24,7 +26,8 @@ const (
 	CommandName  = "tokenize"
 	Description  = `command line tokenize`
 	usageMessage = "%s [-file input_file] [-dict dic_file] [-userdict user_dic_file]" +
-		" [-sysdict (ipa|uni)] [-simple false] [-mode (normal|search|extended)] [-split] [-json]"
+		" [-sysdict (ipa|uni)] [-simple false] [-mode (normal|search|extended)] [-split] [-bunsetsu] [-json]" +
+		" [-filter] [-stop-words stop_words_file] [-stop-tags stop_tags_file]"
 )
 
 var (
@@ -36,15 +39,19 @@ var (
 
 // options
 type option struct {
-	file    string
-	dict    string
-	udict   string
-	sysdict string
-	simple  bool
-	mode    string
-	split   bool
-	json    bool
-	flagSet *flag.FlagSet
+	file      string
+	dict      string
+	udict     string
+	sysdict   string
+	simple    bool
+	mode      string
+	split     bool
+	bunsetsu  bool
+	json      bool
+	filter    bool
+	stopWords string
+	stopTags  string
+	flagSet   *flag.FlagSet
 }
 
 // ContinueOnError ErrorHandling // Return a descriptive error.
@@ -63,7 +70,11 @@ func newOption(w io.Writer, eh flag.ErrorHandling) (o *option) {
 	o.flagSet.BoolVar(&o.simple, "simple", false, "display abbreviated dictionary contents")
 	o.flagSet.StringVar(&o.mode, "mode", "normal", "tokenize mode (normal|search|extended)")
 	o.flagSet.BoolVar(&o.split, "split", false, "use tiny sentence splitter")
+	o.flagSet.BoolVar(&o.bunsetsu, "bunsetsu", false, "outputs bunsetsu (phrase) chunks")
 	o.flagSet.BoolVar(&o.json, "json", false, "outputs in JSON format")
+	o.flagSet.BoolVar(&o.filter, "filter", false, "drops the stop words and the stop tags of the japanese filter")
+	o.flagSet.StringVar(&o.stopWords, "stop-words", "", "stop words file which replaces the default stop words (implies -filter)")
+	o.flagSet.StringVar(&o.stopTags, "stop-tags", "", "stop tags file which replaces the default stop tags (implies -filter)")
 
 	return
 }
@@ -82,6 +93,12 @@ func (o *option) parse(args []string) error {
 	if o.sysdict != "" && o.sysdict != "ipa" && o.sysdict != "uni" {
 		return fmt.Errorf("invalid argument: -sysdict %v", o.sysdict)
 	}
+	if o.stopWords != "" || o.stopTags != "" {
+		o.filter = true
+	}
+	if o.filter && o.bunsetsu {
+		return errors.New("invalid argument: -bunsetsu cannot be used with the filter")
+	}
 	return nil
 }
 
@@ -128,6 +145,28 @@ func selectMode(mode string) tokenizer.TokenizeMode {
 	return tokenizer.Normal
 }
 
+func newFilter(opt *option) (*ja.Filter, error) {
+	var opts []ja.FilterOption
+	if opt.stopWords != "" {
+		o, err := ja.StopWordsFilterOptionFromFile(opt.stopWords)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, o)
+	}
+	if opt.stopTags != "" {
+		o, err := ja.StopTagsFilterOptionFromFile(opt.stopTags)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, o)
+	}
+	if opt.sysdict == "uni" {
+		return ja.NewUniDicFilter(opts...)
+	}
+	return ja.NewFilter(opts...)
+}
+
 func command(_ context.Context, opt *option) error {
 	d, err := selectDict(opt.dict, opt.sysdict, opt.simple)
 	if err != nil {
@@ -135,7 +174,7 @@ func command(_ context.Context, opt *option) error {
 	}
 	udict := tokenizer.Nop()
 	if opt.udict != "" {
-		d, err := dict.NewUserDict(opt.udict)
+		d, err := userdict.Load(opt.udict)
 		if err != nil {
 			return err
 		}
@@ -145,6 +184,12 @@ func command(_ context.Context, opt *option) error {
 	if err != nil {
 		return err
 	}
+	var f *ja.Filter
+	if opt.filter || opt.stopWords != "" || opt.stopTags != "" {
+		if f, err = newFilter(opt); err != nil {
+			return err
+		}
+	}
 
 	fp := os.Stdin
 	if opt.file != "" {
@@ -162,8 +207,26 @@ func command(_ context.Context, opt *option) error {
 	if opt.split {
 		s.Split(filter.ScanSentences)
 	}
+	chunker := ja.NewChunker()
+	if opt.sysdict == "uni" {
+		chunker = ja.NewUniDicChunker()
+	}
 	for s.Scan() {
 		tokens := t.Analyze(s.Text(), mode)
+		if opt.bunsetsu {
+			chunks := chunker.Chunk(tokens)
+			if !opt.json {
+				printChunks(chunks)
+				continue
+			}
+			if err := printChunksJSON(chunks); err != nil {
+				return err
+			}
+			continue
+		}
+		if f != nil {
+			f.Drop(&tokens)
+		}
 		if !opt.json {
 			printTokens(tokens)
 			continue
@@ -218,6 +281,66 @@ func printTokensJSON(tokens []tokenizer.Token) error {
 	return nil
 }
 
+// chunkData is a data format of a bunsetsu chunk.
+type chunkData struct {
+	Surface  string                `json:"surface"`
+	Start    int                   `json:"start"`
+	End      int                   `json:"end"`
+	Head     int                   `json:"head"`
+	Function int                   `json:"func"`
+	Tokens   []tokenizer.TokenData `json:"tokens"`
+}
+
+// printChunks prints chunks in the CaboCha-like format. A chunk starts
+// with a line "* index head/func" followed by its tokens.
+func printChunks(chunks []ja.Chunk) {
+	w := bufio.NewWriter(Stdout)
+	defer w.Flush()
+	for i, c := range chunks {
+		fmt.Fprintf(w, "* %d %d/%d\n", i, c.Head, c.Function)
+		for _, v := range c.Tokens {
+			w.WriteString(v.Surface)
+			w.WriteString("\t")
+			w.WriteString(strings.Join(v.Features(), ","))
+			w.WriteString("\n")
+		}
+	}
+	w.WriteString("EOS\n")
+}
+
+func printChunksJSON(chunks []ja.Chunk) error {
+	w := bufio.NewWriter(Stdout)
+	defer w.Flush()
+
+	if len(chunks) > 0 {
+		w.WriteString("[\n")
+	}
+	var array [][]byte
+	for _, c := range chunks {
+		r := chunkData{
+			Surface:  c.Surface(),
+			Start:    c.Start,
+			End:      c.End,
+			Head:     c.Head,
+			Function: c.Function,
+			Tokens:   make([]tokenizer.TokenData, 0, len(c.Tokens)),
+		}
+		for _, v := range c.Tokens {
+			r.Tokens = append(r.Tokens, tokenizer.NewTokenData(v))
+		}
+		obj, err := json.Marshal(r)
+		if err != nil {
+			return err
+		}
+		array = append(array, obj)
+	}
+	w.Write(bytes.Join(array, []byte(",\n")))
+	if len(chunks) > 0 {
+		w.WriteString("\n]\n")
+	}
+	return nil
+}
+
 // Run receives the slice of args and executes the tokenize tool
 func Run(ctx context.Context, args []string) error {
 	opt := newOption(Stderr, flag.ContinueOnError)
@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"path/filepath"
 	"testing"
 
 	"github.com/ikawaha/kagome/v2/tokenizer"
@@ -119,6 +120,99 @@ func TestCommand_JSONOutput(t *testing.T) {
 	}
 }
 
+func TestCommand_Bunsetsu(t *testing.T) {
+	// input
+	{
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("unexpected pipe error, %v", err)
+		}
+		stdin := os.Stdin
+		os.Stdin = r
+		defer func() {
+			os.Stdin = stdin
+		}()
+		go func() {
+			fmt.Fprintf(w, "赤い蝋燭と人魚。")
+			w.Close()
+		}()
+	}
+	// output
+	var b bytes.Buffer
+	stdout := Stdout
+	Stdout = &b
+	defer func() {
+		Stdout = stdout
+	}()
+
+	// test
+	if err := command(context.TODO(), &option{
+		dict:     "../../testdata/ipa.dict",
+		bunsetsu: true,
+	}); err != nil {
+		t.Errorf("unexpected error, command failed, %v", err)
+	}
+	want := `* 0 0/-1
+赤い	形容詞,自立,*,*,形容詞・アウオ段,基本形,赤い,アカイ,アカイ
+* 1 0/1
+蝋燭	名詞,一般,*,*,*,*,蝋燭,ロウソク,ローソク
+と	助詞,並立助詞,*,*,*,*,と,ト,ト
+* 2 0/-1
+人魚	名詞,一般,*,*,*,*,人魚,ニンギョ,ニンギョ
+。	記号,句点,*,*,*,*,。,。,。
+EOS
+`
+	if got := b.String(); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
+func TestCommand_Filter(t *testing.T) {
+	// input
+	{
+		r, w, err := os.Pipe()
+		if err != nil {
+			t.Fatalf("unexpected pipe error, %v", err)
+		}
+		stdin := os.Stdin
+		os.Stdin = r
+		defer func() {
+			os.Stdin = stdin
+		}()
+		go func() {
+			fmt.Fprintf(w, "赤い蝋燭と人魚。")
+			w.Close()
+		}()
+	}
+	// output
+	var b bytes.Buffer
+	stdout := Stdout
+	Stdout = &b
+	defer func() {
+		Stdout = stdout
+	}()
+
+	stopWords := filepath.Join(t.TempDir(), "stop_words.txt")
+	if err := os.WriteFile(stopWords, []byte("# stop words\n人魚\n"), 0o600); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+
+	// test
+	if err := command(context.TODO(), &option{
+		dict:      "../../testdata/ipa.dict",
+		stopWords: stopWords,
+	}); err != nil {
+		t.Errorf("unexpected error, command failed, %v", err)
+	}
+	want := `赤い	形容詞,自立,*,*,形容詞・アウオ段,基本形,赤い,アカイ,アカイ
+蝋燭	名詞,一般,*,*,*,*,蝋燭,ロウソク,ローソク
+EOS
+`
+	if got := b.String(); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
+
 func TestCommand_JSONOutput_issue249(t *testing.T) {
 	// input
 	{
@@ -220,10 +314,25 @@ func TestOptionCheck(t *testing.T) {
 				"-simple",
 				"-mode", "search",
 				"-split",
+				"-bunsetsu",
 				"-json",
 			},
 			wantErr: false,
 		},
+		{
+			name: "filter options",
+			args: []string{
+				"-filter",
+				"-stop-words", "<stop_words>",
+				"-stop-tags", "<stop_tags>",
+			},
+			wantErr: false,
+		},
+		{
+			name:    "filter with bunsetsu",
+			args:    []string{"-stop-words", "<stop_words>", "-bunsetsu"},
+			wantErr: true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
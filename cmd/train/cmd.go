@@ -0,0 +1,172 @@
+package train
+
+import (
+	"archive/zip"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome-dict/uni"
+	"github.com/ikawaha/kagome/v2/corpus"
+	"github.com/ikawaha/kagome/v2/train"
+)
+
+// subcommand property
+const (
+	CommandName  = "train"
+	Description  = `learn dictionary costs from an annotated corpus`
+	usageMessage = "%s -corpus corpus_file -output dict_file [-dict dic_file] [-sysdict (ipa|uni)] [-epoch n] [-rate n]"
+)
+
+var (
+	// Stdout is the standard writer.
+	Stdout io.Writer = os.Stdout
+	// Stderr is the standard error writer.
+	Stderr io.Writer = os.Stderr
+)
+
+// options
+type option struct {
+	corpus  string
+	output  string
+	dict    string
+	sysdict string
+	epoch   int
+	rate    int
+	flagSet *flag.FlagSet
+}
+
+// ContinueOnError ErrorHandling // Return a descriptive error.
+// ExitOnError                   // Call os.Exit(2).
+// PanicOnError                  // Call panic with a descriptive error.flag.ContinueOnError
+func newOption(w io.Writer, eh flag.ErrorHandling) (o *option) {
+	o = &option{
+		flagSet: flag.NewFlagSet(CommandName, eh),
+	}
+	// option settings
+	o.flagSet.SetOutput(w)
+	o.flagSet.StringVar(&o.corpus, "corpus", "", "segmented and POS-tagged corpus in the MeCab output format")
+	o.flagSet.StringVar(&o.output, "output", "", "output dictionary file")
+	o.flagSet.StringVar(&o.dict, "dict", "", "dict")
+	o.flagSet.StringVar(&o.sysdict, "sysdict", "ipa", "system dict type (ipa|uni)")
+	o.flagSet.IntVar(&o.epoch, "epoch", 10, "maximum number of epochs")
+	o.flagSet.IntVar(&o.rate, "rate", train.DefaultRate, "amount of a cost update")
+	return
+}
+
+func (o *option) parse(args []string) error {
+	if err := o.flagSet.Parse(args); err != nil {
+		return err
+	}
+	// validations
+	if nonFlag := o.flagSet.Args(); len(nonFlag) != 0 {
+		return fmt.Errorf("invalid argument: %v", nonFlag)
+	}
+	if o.corpus == "" {
+		return errors.New("corpus file is not specified")
+	}
+	if o.output == "" {
+		return errors.New("output file is not specified")
+	}
+	if o.sysdict != "" && o.sysdict != "ipa" && o.sysdict != "uni" {
+		return fmt.Errorf("invalid argument: -sysdict %v", o.sysdict)
+	}
+	if o.epoch <= 0 {
+		return fmt.Errorf("invalid argument: -epoch %v", o.epoch)
+	}
+	if o.rate <= 0 {
+		return fmt.Errorf("invalid argument: -rate %v", o.rate)
+	}
+	return nil
+}
+
+// OptionCheck receives a slice of args and returns an error if it was not successfully parsed
+func OptionCheck(args []string) error {
+	opt := newOption(io.Discard, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return nil
+}
+
+func selectDict(path, sysdict string) (*dict.Dict, error) {
+	if path != "" {
+		return dict.LoadDictFile(path)
+	}
+	switch sysdict {
+	case "ipa":
+		return ipa.Dict(), nil
+	case "uni":
+		return uni.Dict(), nil
+	}
+	return nil, fmt.Errorf("unknown dict type, %v", sysdict)
+}
+
+func command(_ context.Context, opt *option) error {
+	d, err := selectDict(opt.dict, opt.sysdict)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(opt.corpus)
+	if err != nil {
+		return err
+	}
+	c, err := corpus.ReadMeCab(f)
+	_ = f.Close()
+	if err != nil {
+		return err
+	}
+	t, err := train.New(d)
+	if err != nil {
+		return err
+	}
+	t.Rate = opt.rate
+	stats, err := t.Train(c, opt.epoch)
+	if err != nil {
+		return err
+	}
+	for _, v := range stats {
+		fmt.Fprintf(Stdout, "epoch %d: %d/%d sentences incorrect, %d skipped\n", v.Epoch, v.Errors, v.Sentences, v.Skipped)
+	}
+	w, err := os.OpenFile(opt.output, os.O_RDWR|os.O_TRUNC|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	zw := zip.NewWriter(w)
+	if err := t.Dict().Save(zw); err != nil {
+		_ = w.Close()
+		return err
+	}
+	if err := zw.Close(); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// Run receives the slice of args and executes the train tool
+func Run(ctx context.Context, args []string) error {
+	opt := newOption(Stderr, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		Usage()
+		PrintDefaults(flag.ContinueOnError)
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return command(ctx, opt)
+}
+
+// Usage provides information on the use of the train tool
+func Usage() {
+	fmt.Fprintf(Stderr, usageMessage+"\n", CommandName)
+}
+
+// PrintDefaults prints out the default flags
+func PrintDefaults(eh flag.ErrorHandling) {
+	o := newOption(Stderr, eh)
+	o.flagSet.PrintDefaults()
+}
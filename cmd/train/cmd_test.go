@@ -0,0 +1,108 @@
+package train
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+const testDictPath = "../../testdata/ipa.dict"
+
+func TestOptionCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name:    "empty args",
+			args:    []string{},
+			wantErr: true,
+		},
+		{
+			name:    "no output",
+			args:    []string{"-corpus", "corpus.txt"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid epoch",
+			args:    []string{"-corpus", "corpus.txt", "-output", "out.dict", "-epoch", "0"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid rate",
+			args:    []string{"-corpus", "corpus.txt", "-output", "out.dict", "-rate", "-1"},
+			wantErr: true,
+		},
+		{
+			name: "all options",
+			args: []string{
+				"-corpus", "corpus.txt",
+				"-output", "out.dict",
+				"-dict", testDictPath,
+				"-sysdict", "uni",
+				"-epoch", "3",
+				"-rate", "5",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := OptionCheck(tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("OptionCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+	var b bytes.Buffer
+	stdout := Stdout
+	Stdout = &b
+	defer func() {
+		Stdout = stdout
+	}()
+
+	dir := t.TempDir()
+	in := filepath.Join(dir, "corpus.txt")
+	const gold = `関西	名詞,固有名詞,地域,一般,*,*,関西,カンサイ,カンサイ
+国際	名詞,一般,*,*,*,*,国際,コクサイ,コクサイ
+空港	名詞,一般,*,*,*,*,空港,クウコウ,クーコー
+EOS
+`
+	if err := os.WriteFile(in, []byte(gold), 0o600); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	out := filepath.Join(dir, "out.dict")
+	if err := command(context.TODO(), &option{
+		corpus: in,
+		output: out,
+		dict:   testDictPath,
+		epoch:  100,
+		rate:   100,
+	}); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(b.String()), "\n")
+	if got, want := lines[len(lines)-1], "0/1 sentences incorrect, 0 skipped"; !strings.HasSuffix(got, want) {
+		t.Errorf("got %q, want suffix %q", got, want)
+	}
+	d, err := dict.LoadDictFile(out)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tnz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if got, want := strings.Join(tnz.Wakati("関西国際空港"), "/"), "関西/国際/空港"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
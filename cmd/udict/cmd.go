@@ -0,0 +1,151 @@
+package udict
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome-dict/uni"
+	"github.com/ikawaha/kagome/v2/userdict"
+)
+
+// subcommand property
+const (
+	CommandName  = "udict"
+	Description  = `validate and compile a user dictionary`
+	usageMessage = "%s [-sysdict (ipa|uni|none)] [-output compiled_file] user_dict_file"
+)
+
+var (
+	// Stdout is the standard writer.
+	Stdout io.Writer = os.Stdout
+	// Stderr is the standard error writer.
+	Stderr io.Writer = os.Stderr
+)
+
+// options
+type option struct {
+	sysdict string
+	output  string
+	input   string
+	flagSet *flag.FlagSet
+}
+
+// ContinueOnError ErrorHandling // Return a descriptive error.
+// ExitOnError                   // Call os.Exit(2).
+// PanicOnError                  // Call panic with a descriptive error.flag.ContinueOnError
+func newOption(w io.Writer, eh flag.ErrorHandling) (o *option) {
+	o = &option{
+		flagSet: flag.NewFlagSet(CommandName, eh),
+	}
+	// option settings
+	o.flagSet.SetOutput(w)
+	o.flagSet.StringVar(&o.sysdict, "sysdict", "ipa", "system dict type to check shadowing entries (ipa|uni|none)")
+	o.flagSet.StringVar(&o.output, "output", "", "output file of the compiled user dictionary")
+	return
+}
+
+func (o *option) parse(args []string) error {
+	if err := o.flagSet.Parse(args); err != nil {
+		return err
+	}
+	// validations
+	if o.flagSet.NArg() != 1 {
+		return errors.New("a user dictionary file is required")
+	}
+	if o.sysdict != "" && o.sysdict != "ipa" && o.sysdict != "uni" && o.sysdict != "none" {
+		return fmt.Errorf("invalid argument: -sysdict %v", o.sysdict)
+	}
+	o.input = o.flagSet.Arg(0)
+	return nil
+}
+
+// OptionCheck receives a slice of args and returns an error if it was not successfully parsed
+func OptionCheck(args []string) error {
+	opt := newOption(io.Discard, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return nil
+}
+
+func selectDict(name string) (*dict.Dict, error) {
+	switch name {
+	case "ipa":
+		return ipa.Dict(), nil
+	case "uni":
+		return uni.Dict(), nil
+	case "", "none":
+		return nil, nil
+	}
+	return nil, fmt.Errorf("unknown name type, %v", name)
+}
+
+func command(_ context.Context, opt *option) error {
+	d, err := selectDict(opt.sysdict)
+	if err != nil {
+		return err
+	}
+	f, err := os.Open(opt.input)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	ret, err := userdict.Validate(f, d)
+	if err != nil {
+		return err
+	}
+	var errs int
+	for _, v := range ret.Diagnostics {
+		if v.Severity == userdict.Error {
+			errs++
+		}
+		fmt.Fprintf(Stdout, "%s:%v\n", opt.input, v)
+	}
+	if errs > 0 {
+		return fmt.Errorf("%d error(s) found", errs)
+	}
+	if opt.output == "" {
+		return nil
+	}
+	u, err := ret.Records.NewUserDict()
+	if err != nil {
+		return err
+	}
+	w, err := os.OpenFile(opt.output, os.O_RDWR|os.O_TRUNC|os.O_CREATE, 0o644)
+	if err != nil {
+		return err
+	}
+	if err := userdict.Save(w, u); err != nil {
+		_ = w.Close()
+		return err
+	}
+	return w.Close()
+}
+
+// Run receives the slice of args and executes the udict tool
+func Run(ctx context.Context, args []string) error {
+	opt := newOption(Stderr, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		Usage()
+		PrintDefaults(flag.ContinueOnError)
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return command(ctx, opt)
+}
+
+// Usage provides information on the use of the udict tool
+func Usage() {
+	fmt.Fprintf(Stderr, usageMessage+"\n", CommandName)
+}
+
+// PrintDefaults prints out the default flags
+func PrintDefaults(eh flag.ErrorHandling) {
+	o := newOption(Stderr, eh)
+	o.flagSet.PrintDefaults()
+}
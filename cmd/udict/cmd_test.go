@@ -0,0 +1,106 @@
+package udict
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/ikawaha/kagome/v2/userdict"
+)
+
+func TestOptionCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name:    "empty args",
+			args:    []string{},
+			wantErr: true,
+		},
+		{
+			name:    "input only",
+			args:    []string{"../../testdata/userdict.txt"},
+			wantErr: false,
+		},
+		{
+			name:    "too many inputs",
+			args:    []string{"a.txt", "b.txt"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid sysdict",
+			args:    []string{"-sysdict", "piyo", "a.txt"},
+			wantErr: true,
+		},
+		{
+			name:    "all options",
+			args:    []string{"-sysdict", "none", "-output", "out.dict", "a.txt"},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := OptionCheck(tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("OptionCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+	var b bytes.Buffer
+	stdout := Stdout
+	Stdout = &b
+	defer func() {
+		Stdout = stdout
+	}()
+
+	t.Run("compile", func(t *testing.T) {
+		b.Reset()
+		out := filepath.Join(t.TempDir(), "userdict.bin")
+		if err := command(context.TODO(), &option{
+			sysdict: "none",
+			output:  out,
+			input:   "../../testdata/userdict.txt",
+		}); err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		if got := b.String(); got != "" {
+			t.Errorf("unexpected diagnostics, %q", got)
+		}
+		d, err := userdict.Load(out)
+		if err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		if got, want := len(d.Contents), 3; got != want {
+			t.Errorf("got %d, want %d", got, want)
+		}
+	})
+	t.Run("invalid entries", func(t *testing.T) {
+		b.Reset()
+		dir := t.TempDir()
+		in := filepath.Join(dir, "broken.txt")
+		if err := os.WriteFile(in, []byte("東京都庁,東京 都,トウキョウ トチョウ,カスタム名詞\n"), 0o600); err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		out := filepath.Join(dir, "userdict.bin")
+		if err := command(context.TODO(), &option{
+			sysdict: "none",
+			output:  out,
+			input:   in,
+		}); err == nil {
+			t.Error("expected error, but no error")
+		}
+		want := in + `:1: error: tokens "東京都" do not concatenate to the surface "東京都庁"` + "\n"
+		if got := b.String(); got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+		if _, err := os.Stat(out); !os.IsNotExist(err) {
+			t.Errorf("compiled file should not be created, %v", err)
+		}
+	})
+}
@@ -0,0 +1,209 @@
+package unknown
+
+import (
+	"bufio"
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/ipa"
+	"github.com/ikawaha/kagome-dict/uni"
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/ikawaha/kagome/v2/unknown"
+	"github.com/ikawaha/kagome/v2/userdict"
+)
+
+// subcommand property
+const (
+	CommandName  = "unknown"
+	Description  = `mine unknown words as user dictionary candidates`
+	usageMessage = "%s [-file input_file] [-dict dic_file] [-udict user_dic_file] [-sysdict (ipa|uni)]" +
+		" [-mode (normal|search|extended)] [-split] [-min n] [-limit n] [-pos part_of_speech]"
+)
+
+var (
+	// Stdout is the standard writer.
+	Stdout io.Writer = os.Stdout
+	// Stderr is the standard error writer.
+	Stderr io.Writer = os.Stderr
+)
+
+// options
+type option struct {
+	file    string
+	dict    string
+	udict   string
+	sysdict string
+	mode    string
+	split   bool
+	min     int
+	limit   int
+	pos     string
+	flagSet *flag.FlagSet
+}
+
+// ContinueOnError ErrorHandling // Return a descriptive error.
+// ExitOnError                   // Call os.Exit(2).
+// PanicOnError                  // Call panic with a descriptive error.flag.ContinueOnError
+func newOption(w io.Writer, eh flag.ErrorHandling) (o *option) {
+	o = &option{
+		flagSet: flag.NewFlagSet(CommandName, eh),
+	}
+	// option settings
+	o.flagSet.SetOutput(w)
+	o.flagSet.StringVar(&o.file, "file", "", "input file")
+	o.flagSet.StringVar(&o.dict, "dict", "", "dict")
+	o.flagSet.StringVar(&o.udict, "udict", "", "user dict")
+	o.flagSet.StringVar(&o.sysdict, "sysdict", "ipa", "system dict type (ipa|uni)")
+	o.flagSet.StringVar(&o.mode, "mode", "normal", "tokenize mode (normal|search|extended)")
+	o.flagSet.BoolVar(&o.split, "split", false, "use tiny sentence splitter")
+	o.flagSet.IntVar(&o.min, "min", 2, "minimum frequency of a candidate")
+	o.flagSet.IntVar(&o.limit, "limit", 0, "maximum number of candidates (0: unlimited)")
+	o.flagSet.StringVar(&o.pos, "pos", "名詞", "part-of-speech of the candidate entries")
+	return
+}
+
+func (o *option) parse(args []string) error {
+	if err := o.flagSet.Parse(args); err != nil {
+		return err
+	}
+	// validations
+	if nonFlag := o.flagSet.Args(); len(nonFlag) != 0 {
+		return fmt.Errorf("invalid argument: %v", nonFlag)
+	}
+	if o.mode != "" && o.mode != "normal" && o.mode != "search" && o.mode != "extended" {
+		return fmt.Errorf("invalid argument: -mode %v", o.mode)
+	}
+	if o.sysdict != "" && o.sysdict != "ipa" && o.sysdict != "uni" {
+		return fmt.Errorf("invalid argument: -sysdict %v", o.sysdict)
+	}
+	if o.min <= 0 {
+		return fmt.Errorf("invalid argument: -min %v", o.min)
+	}
+	if o.limit < 0 {
+		return fmt.Errorf("invalid argument: -limit %v", o.limit)
+	}
+	if o.pos == "" {
+		return fmt.Errorf("invalid argument: -pos %q", o.pos)
+	}
+	return nil
+}
+
+// OptionCheck receives a slice of args and returns an error if it was not successfully parsed
+func OptionCheck(args []string) error {
+	opt := newOption(io.Discard, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return nil
+}
+
+func selectDict(path, sysdict string) (*dict.Dict, error) {
+	if path != "" {
+		return dict.LoadDictFile(path)
+	}
+	switch sysdict {
+	case "ipa":
+		return ipa.Dict(), nil
+	case "uni":
+		return uni.Dict(), nil
+	}
+	return nil, fmt.Errorf("unknown dict type, %v", sysdict)
+}
+
+func selectMode(mode string) tokenizer.TokenizeMode {
+	switch mode {
+	case "normal":
+		return tokenizer.Normal
+	case "search":
+		return tokenizer.Search
+	case "extended":
+		return tokenizer.Extended
+	}
+	return tokenizer.Normal
+}
+
+func command(_ context.Context, opt *option) error {
+	d, err := selectDict(opt.dict, opt.sysdict)
+	if err != nil {
+		return err
+	}
+	udict := tokenizer.Nop()
+	if opt.udict != "" {
+		d, err := userdict.Load(opt.udict)
+		if err != nil {
+			return err
+		}
+		udict = tokenizer.UserDict(d)
+	}
+	t, err := tokenizer.New(d, udict, tokenizer.OmitBosEos())
+	if err != nil {
+		return err
+	}
+
+	fp := os.Stdin
+	if opt.file != "" {
+		var err error
+		fp, err = os.Open(opt.file)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = fp.Close()
+		}()
+	}
+	m := unknown.NewMiner(t, selectMode(opt.mode))
+	s := bufio.NewScanner(fp)
+	if opt.split {
+		s.Split(filter.ScanSentences)
+	}
+	for s.Scan() {
+		m.Add(s.Text())
+	}
+	if err := s.Err(); err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(Stdout)
+	defer w.Flush()
+	for i, v := range m.Candidates(opt.min) {
+		if opt.limit > 0 && i >= opt.limit {
+			break
+		}
+		if v.Reading == "" {
+			// leave the entry commented out for manual review.
+			fmt.Fprintf(w, "# %s: %d occurrences, %d contexts, no reading\n", v.Kind, v.Count, v.Contexts)
+			fmt.Fprintf(w, "# %s,%s,,%s\n", v.Surface, v.Surface, opt.pos)
+			continue
+		}
+		fmt.Fprintf(w, "# %s: %d occurrences, %d contexts\n", v.Kind, v.Count, v.Contexts)
+		fmt.Fprintf(w, "%s,%s,%s,%s\n", v.Surface, v.Surface, v.Reading, opt.pos)
+	}
+	return nil
+}
+
+// Run receives the slice of args and executes the unknown tool
+func Run(ctx context.Context, args []string) error {
+	opt := newOption(Stderr, flag.ContinueOnError)
+	if err := opt.parse(args); err != nil {
+		Usage()
+		PrintDefaults(flag.ContinueOnError)
+		return fmt.Errorf("%v, %w", CommandName, err)
+	}
+	return command(ctx, opt)
+}
+
+// Usage provides information on the use of the unknown tool
+func Usage() {
+	fmt.Fprintf(Stderr, usageMessage+"\n", CommandName)
+}
+
+// PrintDefaults prints out the default flags
+func PrintDefaults(eh flag.ErrorHandling) {
+	o := newOption(Stderr, eh)
+	o.flagSet.PrintDefaults()
+}
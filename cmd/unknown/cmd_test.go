@@ -0,0 +1,107 @@
+package unknown
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+const testDictPath = "../../testdata/ipa.dict"
+
+func TestOptionCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{
+			name:    "no options",
+			args:    []string{},
+			wantErr: false,
+		},
+		{
+			name:    "non flag options",
+			args:    []string{"piyo"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid min",
+			args:    []string{"-min", "0"},
+			wantErr: true,
+		},
+		{
+			name:    "invalid limit",
+			args:    []string{"-limit", "-1"},
+			wantErr: true,
+		},
+		{
+			name:    "empty pos",
+			args:    []string{"-pos", ""},
+			wantErr: true,
+		},
+		{
+			name: "all options",
+			args: []string{
+				"-file", "input.txt",
+				"-dict", testDictPath,
+				"-udict", "../../testdata/userdict.txt",
+				"-sysdict", "uni",
+				"-mode", "search",
+				"-split",
+				"-min", "3",
+				"-limit", "10",
+				"-pos", "カスタム名詞",
+			},
+			wantErr: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := OptionCheck(tt.args); (err != nil) != tt.wantErr {
+				t.Errorf("OptionCheck() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCommand(t *testing.T) {
+	var b bytes.Buffer
+	stdout := Stdout
+	Stdout = &b
+	defer func() {
+		Stdout = stdout
+	}()
+
+	in := filepath.Join(t.TempDir(), "input.txt")
+	const input = `檸檬爆弾
+檸檬を買う
+データサイエンスの勉強
+kagomeで解析
+kagomeで解析
+`
+	if err := os.WriteFile(in, []byte(input), 0o600); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if err := command(context.TODO(), &option{
+		file:  in,
+		dict:  testDictPath,
+		mode:  "normal",
+		min:   1,
+		limit: 3,
+		pos:   "カスタム名詞",
+	}); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	want := `# single-chars: 2 occurrences, 2 contexts, no reading
+# 檸檬,檸檬,,カスタム名詞
+# unknown: 2 occurrences, 1 contexts, no reading
+# kagome,kagome,,カスタム名詞
+# katakana-split: 1 occurrences, 1 contexts
+データサイエンス,データサイエンス,データサイエンス,カスタム名詞
+`
+	if got := b.String(); got != want {
+		t.Errorf("got %s, want %s", got, want)
+	}
+}
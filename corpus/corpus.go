@@ -0,0 +1,118 @@
+package corpus
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Token represents an annotated token of a corpus.
+type Token struct {
+	Surface  string
+	Features []string
+}
+
+// Sentence represents an annotated sentence.
+type Sentence []Token
+
+// Text returns the raw text of a sentence.
+func (s Sentence) Text() string {
+	var b strings.Builder
+	for _, v := range s {
+		b.WriteString(v.Surface)
+	}
+	return b.String()
+}
+
+// ReadMeCab reads a corpus in the MeCab output format, that is, each line has
+// a surface and comma separated features delimited by a tab, and each
+// sentence is terminated by an EOS line.
+func ReadMeCab(r io.Reader) ([]Sentence, error) {
+	var (
+		ret []Sentence
+		s   Sentence
+	)
+	scanner := bufio.NewScanner(r)
+	for no := 1; scanner.Scan(); no++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if line == "" {
+			continue
+		}
+		if line == "EOS" {
+			if len(s) > 0 {
+				ret = append(ret, s)
+			}
+			s = nil
+			continue
+		}
+		surface, features, ok := strings.Cut(line, "\t")
+		if !ok || surface == "" {
+			return nil, fmt.Errorf("invalid format at line %d: %q", no, line)
+		}
+		s = append(s, Token{
+			Surface:  surface,
+			Features: strings.Split(features, ","),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(s) > 0 {
+		ret = append(ret, s)
+	}
+	return ret, nil
+}
+
+// ReadCoNLLU reads a corpus in the CoNLL-U format. The features of a token
+// are the hyphen separated elements of the XPOS column, or the UPOS column
+// if the XPOS column is not specified. Multiword token lines and empty nodes
+// are ignored.
+func ReadCoNLLU(r io.Reader) ([]Sentence, error) {
+	const (
+		formColumn = 1
+		uposColumn = 3
+		xposColumn = 4
+		columnSize = 10
+	)
+	var (
+		ret []Sentence
+		s   Sentence
+	)
+	scanner := bufio.NewScanner(r)
+	for no := 1; scanner.Scan(); no++ {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		if line == "" {
+			if len(s) > 0 {
+				ret = append(ret, s)
+			}
+			s = nil
+			continue
+		}
+		cols := strings.Split(line, "\t")
+		if len(cols) != columnSize {
+			return nil, fmt.Errorf("invalid format at line %d: want %d columns, got %d", no, columnSize, len(cols))
+		}
+		if strings.ContainsAny(cols[0], "-.") {
+			continue // multiword token or empty node
+		}
+		pos := cols[xposColumn]
+		if pos == "_" || pos == "" {
+			pos = cols[uposColumn]
+		}
+		s = append(s, Token{
+			Surface:  cols[formColumn],
+			Features: strings.Split(pos, "-"),
+		})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	if len(s) > 0 {
+		ret = append(ret, s)
+	}
+	return ret, nil
+}
@@ -0,0 +1,84 @@
+package corpus_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ikawaha/kagome/v2/corpus"
+)
+
+func TestReadMeCab(t *testing.T) {
+	const input = `ねこ	名詞,一般,*,*,*,*,ねこ,ネコ,ネコ
+です	助動詞,*,*,*,特殊・デス,基本形,です,デス,デス
+EOS
+
+犬	名詞,一般,*,*,*,*,犬,イヌ,イヌ
+EOS
+EOS
+走る	動詞,自立,*,*,五段・ラ行,基本形,走る,ハシル,ハシル
+`
+	got, err := corpus.ReadMeCab(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	want := []corpus.Sentence{
+		{
+			{Surface: "ねこ", Features: []string{"名詞", "一般", "*", "*", "*", "*", "ねこ", "ネコ", "ネコ"}},
+			{Surface: "です", Features: []string{"助動詞", "*", "*", "*", "特殊・デス", "基本形", "です", "デス", "デス"}},
+		},
+		{
+			{Surface: "犬", Features: []string{"名詞", "一般", "*", "*", "*", "*", "犬", "イヌ", "イヌ"}},
+		},
+		{
+			{Surface: "走る", Features: []string{"動詞", "自立", "*", "*", "五段・ラ行", "基本形", "走る", "ハシル", "ハシル"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if got, want := got[0].Text(), "ねこです"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestReadMeCab_Error(t *testing.T) {
+	if _, err := corpus.ReadMeCab(strings.NewReader("ねこ\nEOS\n")); err == nil {
+		t.Error("expected error, but no error")
+	}
+}
+
+func TestReadCoNLLU(t *testing.T) {
+	const input = "# sent_id = test-1\n" +
+		"# text = 猫です\n" +
+		"1\t猫\t猫\tNOUN\t名詞-普通名詞-一般\t_\t0\troot\t_\tSpaceAfter=No\n" +
+		"2\tです\tだ\tAUX\t助動詞\t_\t1\tcop\t_\tSpaceAfter=No\n" +
+		"\n" +
+		"1-2\t行った\t_\t_\t_\t_\t_\t_\t_\t_\n" +
+		"1\t行っ\t行く\tVERB\t_\t_\t0\troot\t_\t_\n" +
+		"1.1\tx\tx\tX\t_\t_\t_\t_\t_\t_\n" +
+		"2\tた\tた\tAUX\t_\t_\t1\taux\t_\t_\n"
+	got, err := corpus.ReadCoNLLU(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	want := []corpus.Sentence{
+		{
+			{Surface: "猫", Features: []string{"名詞", "普通名詞", "一般"}},
+			{Surface: "です", Features: []string{"助動詞"}},
+		},
+		{
+			{Surface: "行っ", Features: []string{"VERB"}},
+			{Surface: "た", Features: []string{"AUX"}},
+		},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestReadCoNLLU_Error(t *testing.T) {
+	if _, err := corpus.ReadCoNLLU(strings.NewReader("1\t猫\t猫\n")); err == nil {
+		t.Error("expected error, but no error")
+	}
+}
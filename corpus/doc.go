@@ -0,0 +1,2 @@
+// Package corpus reads annotated corpora for training and evaluation.
+package corpus
@@ -0,0 +1,2 @@
+// Package eval evaluates the segmentation accuracy against a gold corpus.
+package eval
@@ -0,0 +1,245 @@
+package eval
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ikawaha/kagome/v2/corpus"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// Score represents counts to calculate the precision, the recall and the F1.
+type Score struct {
+	Gold    int
+	System  int
+	Correct int
+}
+
+// Precision returns the precision.
+func (s Score) Precision() float64 {
+	if s.System == 0 {
+		return 0
+	}
+	return float64(s.Correct) / float64(s.System)
+}
+
+// Recall returns the recall.
+func (s Score) Recall() float64 {
+	if s.Gold == 0 {
+		return 0
+	}
+	return float64(s.Correct) / float64(s.Gold)
+}
+
+// F1 returns the harmonic mean of the precision and the recall.
+func (s Score) F1() float64 {
+	p, r := s.Precision(), s.Recall()
+	if p+r == 0 {
+		return 0
+	}
+	return 2 * p * r / (p + r)
+}
+
+// Accuracy represents counts to calculate an accuracy.
+type Accuracy struct {
+	Total   int
+	Correct int
+}
+
+// Rate returns the accuracy.
+func (a Accuracy) Rate() float64 {
+	if a.Total == 0 {
+		return 0
+	}
+	return float64(a.Correct) / float64(a.Total)
+}
+
+// ErrorPattern represents an error pattern and its frequency.
+type ErrorPattern struct {
+	Pattern string
+	Count   int
+}
+
+// Result represents the result of an evaluation.
+type Result struct {
+	Sentences int
+	// Boundaries is the score of the word boundaries inside sentences.
+	Boundaries Score
+	// Words is the score of the words, a word is correct if its span is equal to the gold.
+	Words Score
+	// POS is the accuracy at each level of the POS hierarchy of the correct words.
+	// A level is correct if the POS from the top to the level are equal to the gold.
+	POS    []Accuracy
+	errors map[string]int
+}
+
+// ErrorPatterns returns the n most frequent error patterns. If n <= 0, it
+// returns all the patterns.
+func (r Result) ErrorPatterns(n int) []ErrorPattern {
+	ret := make([]ErrorPattern, 0, len(r.errors))
+	for k, v := range r.errors {
+		ret = append(ret, ErrorPattern{Pattern: k, Count: v})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Count != ret[j].Count {
+			return ret[i].Count > ret[j].Count
+		}
+		return ret[i].Pattern < ret[j].Pattern
+	})
+	if n > 0 && len(ret) > n {
+		ret = ret[:n]
+	}
+	return ret
+}
+
+// DefaultPOSHierarchy is the default depth of the POS hierarchy of gold tokens.
+const DefaultPOSHierarchy = 4
+
+// Evaluator tokenizes gold sentences and accumulates the result.
+type Evaluator struct {
+	// POSHierarchy is the number of the leading features of a gold token
+	// regarded as the POS.
+	POSHierarchy int
+
+	tokenizer *tokenizer.Tokenizer
+	mode      tokenizer.TokenizeMode
+	result    Result
+}
+
+// New returns an evaluator.
+func New(t *tokenizer.Tokenizer, mode tokenizer.TokenizeMode) *Evaluator {
+	return &Evaluator{
+		POSHierarchy: DefaultPOSHierarchy,
+		tokenizer:    t,
+		mode:         mode,
+		result: Result{
+			errors: map[string]int{},
+		},
+	}
+}
+
+// Result returns the accumulated result.
+func (e *Evaluator) Result() Result {
+	return e.result
+}
+
+type word struct {
+	start, end int // rune position
+	surface    string
+	pos        []string
+}
+
+// Add tokenizes the text of a gold sentence and accumulates the result.
+func (e *Evaluator) Add(s corpus.Sentence) {
+	gold := make([]word, 0, len(s))
+	var pos int
+	for _, v := range s {
+		end := pos + utf8.RuneCountInString(v.Surface)
+		p := v.Features
+		if len(p) > e.POSHierarchy {
+			p = p[:e.POSHierarchy]
+		}
+		gold = append(gold, word{start: pos, end: end, surface: v.Surface, pos: trimPOS(p)})
+		pos = end
+	}
+	var sys []word
+	for _, v := range e.tokenizer.Analyze(s.Text(), e.mode) {
+		if v.ID == tokenizer.BosEosID {
+			continue
+		}
+		sys = append(sys, word{start: v.Start, end: v.End, surface: v.Surface, pos: trimPOS(v.POS())})
+	}
+	e.result.Sentences++
+	e.addBoundaries(gold, sys)
+	e.addWords(gold, sys)
+}
+
+// trimPOS removes the undefined elements at the tail of a POS.
+func trimPOS(pos []string) []string {
+	for len(pos) > 0 && (pos[len(pos)-1] == "*" || pos[len(pos)-1] == "") {
+		pos = pos[:len(pos)-1]
+	}
+	return pos
+}
+
+func (e *Evaluator) addBoundaries(gold, sys []word) {
+	boundaries := make(map[int]struct{}, len(gold))
+	for i := 1; i < len(gold); i++ {
+		boundaries[gold[i].start] = struct{}{}
+	}
+	e.result.Boundaries.Gold += len(boundaries)
+	for i := 1; i < len(sys); i++ {
+		e.result.Boundaries.System++
+		if _, ok := boundaries[sys[i].start]; ok {
+			e.result.Boundaries.Correct++
+		}
+	}
+}
+
+func (e *Evaluator) addWords(gold, sys []word) {
+	e.result.Words.Gold += len(gold)
+	e.result.Words.System += len(sys)
+	var i, j int
+	for i < len(gold) && j < len(sys) {
+		if gold[i].start == sys[j].start && gold[i].end == sys[j].end {
+			e.result.Words.Correct++
+			e.addPOS(gold[i], sys[j])
+			i++
+			j++
+			continue
+		}
+		// collect the mismatched region until the both ends are synchronized.
+		gi, sj := i, j
+		i++
+		j++
+		for i < len(gold) || j < len(sys) {
+			ge, se := gold[i-1].end, sys[j-1].end
+			if ge == se {
+				break
+			}
+			if ge < se && i < len(gold) {
+				i++
+			} else if j < len(sys) {
+				j++
+			} else {
+				i++
+			}
+		}
+		e.result.errors[surfaces(gold[gi:i])+" -> "+surfaces(sys[sj:j])]++
+	}
+}
+
+func (e *Evaluator) addPOS(gold, sys word) {
+	for k := range gold.pos {
+		if k >= len(e.result.POS) {
+			e.result.POS = append(e.result.POS, Accuracy{})
+		}
+		e.result.POS[k].Total++
+		if k < len(sys.pos) && equalPOS(gold.pos[:k+1], sys.pos[:k+1]) {
+			e.result.POS[k].Correct++
+		} else if k == 0 || equalPOS(gold.pos[:k], sys.pos[:min(k, len(sys.pos))]) {
+			// report only the first level where the POS differs.
+			e.result.errors[gold.surface+" "+strings.Join(gold.pos, "-")+" -> "+strings.Join(sys.pos, "-")]++
+		}
+	}
+}
+
+func equalPOS(lhs, rhs []string) bool {
+	return tokenizer.EqualFeatures(lhs, rhs)
+}
+
+func surfaces(words []word) string {
+	ret := make([]string, 0, len(words))
+	for _, v := range words {
+		ret = append(ret, v.surface)
+	}
+	return strings.Join(ret, "/")
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
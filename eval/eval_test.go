@@ -0,0 +1,91 @@
+package eval_test
+
+import (
+	"math"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/corpus"
+	"github.com/ikawaha/kagome/v2/eval"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+const testDictPath = "../testdata/ipa.dict"
+
+func TestScore(t *testing.T) {
+	s := eval.Score{Gold: 4, System: 5, Correct: 3}
+	if got, want := s.Precision(), 0.6; math.Abs(got-want) > 1e-9 {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := s.Recall(), 0.75; math.Abs(got-want) > 1e-9 {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := s.F1(), 2*0.6*0.75/(0.6+0.75); math.Abs(got-want) > 1e-9 {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	var zero eval.Score
+	if zero.Precision() != 0 || zero.Recall() != 0 || zero.F1() != 0 {
+		t.Errorf("unexpected zero score, %v %v %v", zero.Precision(), zero.Recall(), zero.F1())
+	}
+	if got := (eval.Accuracy{}).Rate(); got != 0 {
+		t.Errorf("got %v, want 0", got)
+	}
+}
+
+func TestEvaluator(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tnz, err := tokenizer.New(d)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	// the tokenizer outputs: 関西国際空港 / ねこ(名詞,一般) / です
+	const gold = `関西	名詞,固有名詞,地域,一般,*,*,関西,カンサイ,カンサイ
+国際	名詞,一般,*,*,*,*,国際,コクサイ,コクサイ
+空港	名詞,一般,*,*,*,*,空港,クウコウ,クーコー
+ねこ	名詞,固有名詞,一般,*,*,*,ねこ,ネコ,ネコ
+です	助動詞,*,*,*,特殊・デス,基本形,です,デス,デス
+EOS
+`
+	c, err := corpus.ReadMeCab(strings.NewReader(gold))
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	e := eval.New(tnz, tokenizer.Normal)
+	for _, v := range c {
+		e.Add(v)
+	}
+	r := e.Result()
+	if got, want := r.Sentences, 1; got != want {
+		t.Errorf("sentences: got %d, want %d", got, want)
+	}
+	if got, want := r.Words, (eval.Score{Gold: 5, System: 3, Correct: 2}); got != want {
+		t.Errorf("words: got %+v, want %+v", got, want)
+	}
+	if got, want := r.Boundaries, (eval.Score{Gold: 4, System: 2, Correct: 2}); got != want {
+		t.Errorf("boundaries: got %+v, want %+v", got, want)
+	}
+	want := []eval.Accuracy{
+		{Total: 2, Correct: 2},
+		{Total: 1, Correct: 0},
+		{Total: 1, Correct: 0},
+	}
+	if !reflect.DeepEqual(r.POS, want) {
+		t.Errorf("pos: got %+v, want %+v", r.POS, want)
+	}
+	patterns := r.ErrorPatterns(0)
+	wantPatterns := []eval.ErrorPattern{
+		{Pattern: "ねこ 名詞-固有名詞-一般 -> 名詞-一般", Count: 1},
+		{Pattern: "関西/国際/空港 -> 関西国際空港", Count: 1},
+	}
+	if !reflect.DeepEqual(patterns, wantPatterns) {
+		t.Errorf("patterns: got %+v, want %+v", patterns, wantPatterns)
+	}
+	if got := r.ErrorPatterns(1); len(got) != 1 {
+		t.Errorf("got %+v, want 1 pattern", got)
+	}
+}
@@ -0,0 +1,59 @@
+package filter
+
+import (
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// TokenFilter represents a filter which drops, keeps or transforms tokens.
+type TokenFilter interface {
+	Apply(tokens *[]tokenizer.Token)
+}
+
+// TokenFilterFunc is an adapter to use an ordinary function, e.g. the Drop
+// or Keep method of a filter, as a token filter.
+type TokenFilterFunc func(tokens *[]tokenizer.Token)
+
+// Apply calls f(tokens).
+func (f TokenFilterFunc) Apply(tokens *[]tokenizer.Token) {
+	f(tokens)
+}
+
+// Chain represents a sequence of token filters applied in order.
+type Chain struct {
+	filters []TokenFilter
+}
+
+// NewChain returns a chain of the token filters.
+func NewChain(fs ...TokenFilter) *Chain {
+	return &Chain{
+		filters: fs,
+	}
+}
+
+// Append appends the token filters to the chain.
+func (c *Chain) Append(fs ...TokenFilter) {
+	c.filters = append(c.filters, fs...)
+}
+
+// Apply applies the token filters of the chain in order.
+func (c Chain) Apply(tokens *[]tokenizer.Token) {
+	if tokens == nil {
+		return
+	}
+	for _, f := range c.filters {
+		f.Apply(tokens)
+	}
+}
+
+// Yield returns the surfaces of the tokens passed through the chain.
+// The given tokens are not modified.
+func (c Chain) Yield(tokens []tokenizer.Token) []string {
+	ts := make([]tokenizer.Token, len(tokens))
+	copy(ts, tokens)
+	c.Apply(&ts)
+	var ret []string
+	for _, v := range ts {
+		ret = append(ret, v.Surface)
+	}
+	return ret
+}
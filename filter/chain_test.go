@@ -0,0 +1,73 @@
+package filter_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+func TestChain(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tnz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tokens := tnz.Tokenize("Kagomeで赤い蝋燭を探した")
+
+	testdata := []struct {
+		title   string
+		filters []filter.TokenFilter
+		want    []string
+	}{
+		{
+			title:   "empty chain",
+			filters: nil,
+			want:    []string{"Kagome", "で", "赤い", "蝋燭", "を", "探し", "た"},
+		},
+		{
+			title: "drop and transform",
+			filters: []filter.TokenFilter{
+				filter.TokenFilterFunc(filter.NewPOSFilter(filter.POS{"助詞"}, filter.POS{"助動詞"}).Drop),
+				filter.NewBaseFormFilter(filter.POS{"動詞"}),
+				filter.NewLowercaseFilter(),
+			},
+			want: []string{"kagome", "赤い", "蝋燭", "探す"},
+		},
+		{
+			title: "keep features and reading",
+			filters: []filter.TokenFilter{
+				filter.TokenFilterFunc(filter.NewFeaturesFilter(filter.Features{"名詞", "一般"}).Keep),
+				filter.NewReadingFilter(),
+			},
+			want: []string{"Kagome", "ロウソク"},
+		},
+		{
+			title: "nested chain",
+			filters: []filter.TokenFilter{
+				filter.NewChain(
+					filter.TokenFilterFunc(filter.NewWordFilter([]string{"で", "を", "た"}).Drop),
+				),
+				filter.NewBaseFormFilter(),
+			},
+			want: []string{"Kagome", "赤い", "蝋燭", "探す"},
+		},
+	}
+	for _, v := range testdata {
+		t.Run(v.title, func(t *testing.T) {
+			c := filter.NewChain(v.filters...)
+			got := c.Yield(tokens)
+			if !reflect.DeepEqual(got, v.want) {
+				t.Errorf("got %q, want %q", got, v.want)
+			}
+			if tokens[0].Surface != "Kagome" || len(tokens) != 7 {
+				t.Errorf("tokens are modified, %+v", tokens)
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+package filter
+
+import (
+	"strings"
+
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// CompoundRule represents a rule which joins a token whose part-of-speech
+// matches Left and the following token whose part-of-speech matches Right.
+// A part-of-speech matches if the rule is a prefix of it.
+type CompoundRule struct {
+	Left  POS
+	Right POS
+}
+
+// CompoundFilter represents a filter which merges consecutive tokens into
+// a compound according to the rules.
+type CompoundFilter struct {
+	rules []compoundRule
+}
+
+type compoundRule struct {
+	left  *POSFilter
+	right *POSFilter
+}
+
+// NewCompoundFilter returns a compound filter.
+func NewCompoundFilter(rules ...CompoundRule) *CompoundFilter {
+	ret := &CompoundFilter{
+		rules: make([]compoundRule, 0, len(rules)),
+	}
+	for _, v := range rules {
+		ret.rules = append(ret.rules, compoundRule{
+			left:  NewPOSFilter(v.Left),
+			right: NewPOSFilter(v.Right),
+		})
+	}
+	return ret
+}
+
+func (f CompoundFilter) match(left, right tokenizer.Token) bool {
+	if left.Class == tokenizer.DUMMY || right.Class == tokenizer.DUMMY {
+		return false
+	}
+	lp, rp := left.POS(), right.POS()
+	for _, v := range f.rules {
+		if v.left.Match(lp) && v.right.Match(rp) {
+			return true
+		}
+	}
+	return false
+}
+
+// Apply merges the tokens. A run of tokens in which every adjacent pair
+// matches a rule is replaced with a synthetic token. The part-of-speech of
+// the compound is that of the last token, and the base form is the surfaces
+// followed by the base form of the last token. The reading and the
+// pronunciation are the concatenations of those of the tokens, and they are
+// missing if some token does not have them.
+func (f CompoundFilter) Apply(tokens *[]tokenizer.Token) {
+	if tokens == nil {
+		return
+	}
+	ts := *tokens
+	tail := 0
+	for i := 0; i < len(ts); {
+		j := i + 1
+		for j < len(ts) && f.match(ts[j-1], ts[j]) {
+			j++
+		}
+		if j-i == 1 {
+			ts[tail] = ts[i]
+		} else {
+			ts[tail] = Compound(ts[i:j])
+		}
+		tail++
+		i = j
+	}
+	*tokens = ts[:tail]
+}
+
+// Compound returns a synthetic token which merges the tokens.
+// See CompoundFilter.Apply for the features of the token.
+func Compound(tokens []tokenizer.Token) tokenizer.Token {
+	if len(tokens) == 0 {
+		return tokenizer.NewSyntheticToken("", tokenizer.SyntheticFeatures{})
+	}
+	var surface, base, reading, pron strings.Builder
+	hasReading, hasPron := true, true
+	last := len(tokens) - 1
+	for i, v := range tokens {
+		surface.WriteString(v.Surface)
+		if i < last {
+			base.WriteString(v.Surface)
+		} else if b, ok := v.BaseForm(); ok && b != "*" {
+			base.WriteString(b)
+		} else {
+			base.WriteString(v.Surface)
+		}
+		if r, ok := v.Reading(); ok && r != "*" {
+			reading.WriteString(r)
+		} else {
+			hasReading = false
+		}
+		if p, ok := v.Pronunciation(); ok && p != "*" {
+			pron.WriteString(p)
+		} else {
+			hasPron = false
+		}
+	}
+	f := tokenizer.SyntheticFeatures{
+		POS:      tokens[last].POS(),
+		BaseForm: base.String(),
+	}
+	if hasReading {
+		f.Reading = reading.String()
+	}
+	if hasPron {
+		f.Pronunciation = pron.String()
+	}
+	ret := tokenizer.NewSyntheticToken(surface.String(), f)
+	ret.Index = tokens[0].Index
+	ret.Position = tokens[0].Position
+	ret.Start = tokens[0].Start
+	ret.End = tokens[last].End
+	return ret
+}
@@ -0,0 +1,70 @@
+package filter_test
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+func TestCompoundFilter(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tnz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	f := filter.NewCompoundFilter(
+		filter.CompoundRule{Left: filter.POS{"名詞", "一般"}, Right: filter.POS{"名詞", "一般"}},
+		filter.CompoundRule{Left: filter.POS{"名詞", "一般"}, Right: filter.POS{"名詞", "接尾"}},
+		filter.CompoundRule{Left: filter.POS{"名詞", "接尾"}, Right: filter.POS{"名詞", "サ変接続"}},
+	)
+	tokens := tnz.Tokenize("昨日情報処理技術者試験を受けた")
+	f.Apply(&tokens)
+
+	var got []string
+	for _, v := range tokens {
+		got = append(got, v.Surface)
+	}
+	if want := []string{"昨日", "情報処理技術者試験", "を", "受け", "た"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+	c := tokens[1]
+	if got, want := c.Class, tokenizer.SYNTHETIC; got != want {
+		t.Errorf("class: got %v, want %v", got, want)
+	}
+	if got, want := []int{c.Index, c.Position, c.Start, c.End}, []int{1, 6, 2, 11}; !reflect.DeepEqual(got, want) {
+		t.Errorf("index, position, start, end: got %v, want %v", got, want)
+	}
+	if got, want := c.POS(), []string{"名詞", "サ変接続", "*", "*"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pos: got %v, want %v", got, want)
+	}
+	if got, _ := c.Reading(); got != "ジョウホウショリギジュツシャシケン" {
+		t.Errorf("reading: got %v", got)
+	}
+	if got, _ := c.BaseForm(); got != "情報処理技術者試験" {
+		t.Errorf("base form: got %v", got)
+	}
+}
+
+func TestCompound_MissingReading(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tnz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	c := filter.Compound(tnz.Tokenize("Kagome解析"))
+	if got, want := c.Surface, "Kagome解析"; got != want {
+		t.Errorf("surface: got %v, want %v", got, want)
+	}
+	if got, ok := c.Reading(); ok {
+		t.Errorf("reading: got %v, want missing", got)
+	}
+}
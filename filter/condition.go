@@ -0,0 +1,155 @@
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Condition represents a condition on a vector of features. A features
+// filter (see FeaturesFilter) is also a condition.
+type Condition interface {
+	Match(fs Features) bool
+}
+
+// Equal returns a condition that the i-th feature equals v.
+func Equal(i int, v Feature) Condition {
+	return equalCondition{index: i, value: v}
+}
+
+// Prefix returns a condition that the i-th feature has the prefix.
+func Prefix(i int, prefix string) Condition {
+	return prefixCondition{index: i, prefix: prefix}
+}
+
+// Regexp returns a condition that the i-th feature matches the regular
+// expression.
+func Regexp(i int, re *regexp.Regexp) Condition {
+	return regexpCondition{index: i, re: re}
+}
+
+// Not returns a condition that c does not match.
+func Not(c Condition) Condition {
+	return notCondition{c: c}
+}
+
+// And returns a condition that all of the conditions match.
+func And(cs ...Condition) Condition {
+	return andCondition(cs)
+}
+
+// Or returns a condition that any of the conditions matches.
+func Or(cs ...Condition) Condition {
+	return orCondition(cs)
+}
+
+func featureAt(fs Features, i int) (Feature, bool) {
+	if i < 0 || i >= len(fs) {
+		return "", false
+	}
+	return fs[i], true
+}
+
+type equalCondition struct {
+	index int
+	value Feature
+}
+
+// Match returns true if the condition matches given features.
+func (c equalCondition) Match(fs Features) bool {
+	v, ok := featureAt(fs, c.index)
+	return ok && v == c.value
+}
+
+// String implements string interface.
+func (c equalCondition) String() string {
+	return fmt.Sprintf("[%d] == %q", c.index, c.value)
+}
+
+type prefixCondition struct {
+	index  int
+	prefix string
+}
+
+// Match returns true if the condition matches given features.
+func (c prefixCondition) Match(fs Features) bool {
+	v, ok := featureAt(fs, c.index)
+	return ok && strings.HasPrefix(v, c.prefix)
+}
+
+// String implements string interface.
+func (c prefixCondition) String() string {
+	return fmt.Sprintf("[%d] ^= %q", c.index, c.prefix)
+}
+
+type regexpCondition struct {
+	index int
+	re    *regexp.Regexp
+}
+
+// Match returns true if the condition matches given features.
+func (c regexpCondition) Match(fs Features) bool {
+	v, ok := featureAt(fs, c.index)
+	return ok && c.re.MatchString(v)
+}
+
+// String implements string interface.
+func (c regexpCondition) String() string {
+	return fmt.Sprintf("[%d] =~ /%s/", c.index, c.re)
+}
+
+type notCondition struct {
+	c Condition
+}
+
+// Match returns true if the condition matches given features.
+func (c notCondition) Match(fs Features) bool {
+	return !c.c.Match(fs)
+}
+
+// String implements string interface.
+func (c notCondition) String() string {
+	return fmt.Sprintf("!%v", c.c)
+}
+
+type andCondition []Condition
+
+// Match returns true if the condition matches given features.
+func (c andCondition) Match(fs Features) bool {
+	for _, v := range c {
+		if !v.Match(fs) {
+			return false
+		}
+	}
+	return true
+}
+
+// String implements string interface.
+func (c andCondition) String() string {
+	return joinConditions(c, " && ")
+}
+
+type orCondition []Condition
+
+// Match returns true if the condition matches given features.
+func (c orCondition) Match(fs Features) bool {
+	for _, v := range c {
+		if v.Match(fs) {
+			return true
+		}
+	}
+	return false
+}
+
+// String implements string interface.
+func (c orCondition) String() string {
+	return joinConditions(c, " || ")
+}
+
+func joinConditions(cs []Condition, sep string) string {
+	ss := make([]string, 0, len(cs))
+	for _, v := range cs {
+		ss = append(ss, fmt.Sprint(v))
+	}
+	return "(" + strings.Join(ss, sep) + ")"
+}
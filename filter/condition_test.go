@@ -0,0 +1,103 @@
+package filter_test
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+func TestCondition_Match(t *testing.T) {
+	noun := filter.Features{"名詞", "一般", "*", "*", "*", "*", "蝋燭", "ロウソク", "ローソク"}
+	aux := filter.Features{"助動詞", "*", "*", "*", "特殊・ナイ", "基本形", "ない", "ナイ", "ナイ"}
+	testdata := []struct {
+		title     string
+		condition filter.Condition
+		features  filter.Features
+		want      bool
+	}{
+		{
+			title:     "equal",
+			condition: filter.Equal(0, "名詞"),
+			features:  noun,
+			want:      true,
+		},
+		{
+			title:     "equal out of range",
+			condition: filter.Equal(9, "名詞"),
+			features:  noun,
+			want:      false,
+		},
+		{
+			title:     "prefix",
+			condition: filter.Prefix(4, "特殊"),
+			features:  aux,
+			want:      true,
+		},
+		{
+			title:     "regexp",
+			condition: filter.Regexp(7, regexp.MustCompile(`^ロ`)),
+			features:  noun,
+			want:      true,
+		},
+		{
+			title:     "and",
+			condition: filter.And(filter.Equal(0, "名詞"), filter.Regexp(7, regexp.MustCompile(`^ア`))),
+			features:  noun,
+			want:      false,
+		},
+		{
+			title:     "or",
+			condition: filter.Or(filter.Equal(0, "動詞"), filter.Equal(0, "名詞")),
+			features:  noun,
+			want:      true,
+		},
+		{
+			title:     "not",
+			condition: filter.And(filter.Equal(0, "助動詞"), filter.Not(filter.Equal(6, "ない"))),
+			features:  aux,
+			want:      false,
+		},
+		{
+			title:     "features filter",
+			condition: filter.Not(filter.NewFeaturesFilter(filter.Features{"名詞", filter.Any, "*"})),
+			features:  noun,
+			want:      false,
+		},
+	}
+	for _, v := range testdata {
+		t.Run(v.title, func(t *testing.T) {
+			if got := v.condition.Match(v.features); got != v.want {
+				t.Errorf("want %v, got %v", v.want, got)
+			}
+		})
+	}
+}
+
+func TestFeaturesFilter_AddConditions(t *testing.T) {
+	// the base form of a synthetic token follows the 4 levels of the POS.
+	f := filter.NewFeaturesFilter(filter.Features{"記号"})
+	f.AddConditions(filter.And(filter.Equal(0, "助動詞"), filter.Not(filter.Equal(4, "ない"))))
+
+	tokens := []tokenizer.Token{
+		tokenizer.NewSyntheticToken("食べ", tokenizer.SyntheticFeatures{POS: []string{"動詞", "自立", "*", "*"}, BaseForm: "食べる"}),
+		tokenizer.NewSyntheticToken("ない", tokenizer.SyntheticFeatures{POS: []string{"助動詞", "*", "*", "*"}, BaseForm: "ない"}),
+		tokenizer.NewSyntheticToken("でしょ", tokenizer.SyntheticFeatures{POS: []string{"助動詞", "*", "*", "*"}, BaseForm: "です"}),
+		tokenizer.NewSyntheticToken("。", tokenizer.SyntheticFeatures{POS: []string{"記号", "句点", "*", "*"}}),
+	}
+	f.Drop(&tokens)
+	var got []string
+	for _, v := range tokens {
+		got = append(got, v.Surface)
+	}
+	if want := []string{"食べ", "ない"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("want %+v, got %+v", want, got)
+	}
+
+	want := "記号\n([0] == \"助動詞\" && ![4] == \"ない\")\n"
+	if got := f.String(); got != want {
+		t.Errorf("got:\n%swant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,200 @@
+package filter
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Filter types of the configuration.
+const (
+	POSFilterType       = "pos"
+	WordFilterType      = "word"
+	FeaturesFilterType  = "features"
+	BaseFormFilterType  = "base_form"
+	ReadingFilterType   = "reading"
+	LowercaseFilterType = "lowercase"
+	CompoundFilterType  = "compound"
+)
+
+// Actions of the configuration.
+const (
+	DropAction = "drop"
+	KeepAction = "keep"
+)
+
+// ChainConfig represents a configuration of a chain.
+type ChainConfig struct {
+	Filters []FilterConfig `json:"filters" yaml:"filters"`
+}
+
+// FilterConfig represents a configuration of a token filter.
+// A part-of-speech is described as the features joined with a hyphen,
+// e.g. "名詞-固有名詞", and the pos of the built-in filters accepts the
+// patterns of NewPOSFilterFromPatterns, e.g. "動詞-*,!動詞-非自立". The
+// pos_patterns is for the registered filter types whose pos are exact
+// parts-of-speech, e.g. the stop tags of the japanese filter (filter/ja),
+// and always accepts the patterns. A rule of the compound filter is a pair
+// of the parts-of-speech of the left and right tokens.
+type FilterConfig struct {
+	Type        string     `json:"type" yaml:"type"`
+	Action      string     `json:"action,omitempty" yaml:"action,omitempty"`
+	POS         []string   `json:"pos,omitempty" yaml:"pos,omitempty"`
+	POSPatterns []string   `json:"pos_patterns,omitempty" yaml:"pos_patterns,omitempty"`
+	Words       []string   `json:"words,omitempty" yaml:"words,omitempty"`
+	Features    [][]string `json:"features,omitempty" yaml:"features,omitempty"`
+	Rules       [][]string `json:"rules,omitempty" yaml:"rules,omitempty"`
+}
+
+// FilterFactory builds a token filter from a configuration.
+type FilterFactory func(c FilterConfig) (TokenFilter, error)
+
+var (
+	factoriesMu sync.RWMutex
+	factories   = map[string]FilterFactory{}
+)
+
+// RegisterFilter makes a token filter type available in the configuration.
+// It panics if the type is already registered.
+func RegisterFilter(typ string, f FilterFactory) {
+	factoriesMu.Lock()
+	defer factoriesMu.Unlock()
+	if _, ok := factories[typ]; ok || isBuiltinFilterType(typ) {
+		panic(fmt.Sprintf("filter: RegisterFilter called twice for type %q", typ))
+	}
+	factories[typ] = f
+}
+
+func isBuiltinFilterType(typ string) bool {
+	switch typ {
+	case POSFilterType, WordFilterType, FeaturesFilterType, BaseFormFilterType, ReadingFilterType, LowercaseFilterType, CompoundFilterType:
+		return true
+	}
+	return false
+}
+
+// ReadChainConfig reads a chain configuration in the JSON or YAML format.
+func ReadChainConfig(r io.Reader) (*ChainConfig, error) {
+	b, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	var ret ChainConfig
+	if err := yaml.Unmarshal(b, &ret); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+// LoadChain reads a chain configuration in the JSON or YAML format and builds a chain.
+func LoadChain(r io.Reader) (*Chain, error) {
+	c, err := ReadChainConfig(r)
+	if err != nil {
+		return nil, err
+	}
+	return c.Build()
+}
+
+// Build builds a chain from the configuration.
+func (c ChainConfig) Build() (*Chain, error) {
+	ret := NewChain()
+	for i, v := range c.Filters {
+		f, err := v.Build()
+		if err != nil {
+			return nil, fmt.Errorf("filters[%d]: %w", i, err)
+		}
+		ret.Append(f)
+	}
+	return ret, nil
+}
+
+// Build builds a token filter from the configuration.
+func (c FilterConfig) Build() (TokenFilter, error) {
+	switch c.Type {
+	case POSFilterType:
+		f, err := NewPOSFilterFromPatterns(c.POS...)
+		if err != nil {
+			return nil, err
+		}
+		return dropOrKeep(c.Action, f.Drop, f.Keep)
+	case WordFilterType:
+		f := NewWordFilter(c.Words)
+		return dropOrKeep(c.Action, f.Drop, f.Keep)
+	case FeaturesFilterType:
+		f := NewFeaturesFilter(c.Features...)
+		return dropOrKeep(c.Action, f.Drop, f.Keep)
+	case BaseFormFilterType:
+		f := NewBaseFormFilter()
+		if len(c.POS) > 0 {
+			p, err := NewPOSFilterFromPatterns(c.POS...)
+			if err != nil {
+				return nil, err
+			}
+			f.pos = p
+		}
+		return f, nil
+	case ReadingFilterType:
+		return NewReadingFilter(), nil
+	case LowercaseFilterType:
+		return NewLowercaseFilter(), nil
+	case CompoundFilterType:
+		rules, err := ParseCompoundRules(c.Rules)
+		if err != nil {
+			return nil, err
+		}
+		return NewCompoundFilter(rules...), nil
+	}
+	factoriesMu.RLock()
+	f, ok := factories[c.Type]
+	factoriesMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unknown filter type, %q", c.Type)
+	}
+	return f(c)
+}
+
+func dropOrKeep(action string, drop, keep TokenFilterFunc) (TokenFilter, error) {
+	switch action {
+	case "", DropAction:
+		return drop, nil
+	case KeepAction:
+		return keep, nil
+	}
+	return nil, fmt.Errorf("unknown action, %q", action)
+}
+
+// ParsePOS parses the parts-of-speech described as the features joined with
+// a hyphen, e.g. "名詞-固有名詞".
+func ParsePOS(p []string) []POS {
+	ret := make([]POS, 0, len(p))
+	for _, v := range p {
+		ret = append(ret, strings.Split(v, "-"))
+	}
+	return ret
+}
+
+// ParseCompoundRules parses the rules described as the pairs of the
+// parts-of-speech joined with a hyphen, e.g. ["名詞-一般", "名詞-接尾"].
+// The parts-of-speech are parsed by ParsePOSPattern, so the feature "*"
+// matches any feature, e.g. ["名詞-*-地域", "名詞-接尾"].
+func ParseCompoundRules(rules [][]string) ([]CompoundRule, error) {
+	ret := make([]CompoundRule, 0, len(rules))
+	for i, v := range rules {
+		if len(v) != 2 {
+			return nil, fmt.Errorf("rules[%d]: a rule must be a pair of parts-of-speech, %q", i, v)
+		}
+		left, err := ParsePOSPattern(v[0])
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		right, err := ParsePOSPattern(v[1])
+		if err != nil {
+			return nil, fmt.Errorf("rules[%d]: %w", i, err)
+		}
+		ret = append(ret, CompoundRule{Left: left, Right: right})
+	}
+	return ret, nil
+}
@@ -0,0 +1,128 @@
+package filter_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+func TestLoadChain(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tnz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tokens := tnz.Tokenize("Kagomeで赤い蝋燭を探した")
+
+	testdata := []struct {
+		title  string
+		config string
+		want   []string
+	}{
+		{
+			title: "yaml",
+			config: `filters:
+  - type: pos
+    pos: [助詞, 助動詞]
+  - type: word
+    action: drop
+    words: [赤い]
+  - type: base_form
+    pos: [動詞-自立]
+  - type: lowercase
+`,
+			want: []string{"kagome", "蝋燭", "探す"},
+		},
+		{
+			title: "json",
+			config: `{"filters": [
+  {"type": "features", "action": "keep", "features": [["名詞", "一般"]]},
+  {"type": "reading"}
+]}`,
+			want: []string{"Kagome", "ロウソク"},
+		},
+		{
+			title: "compound",
+			config: `filters:
+  - type: compound
+    rules:
+      - [名詞-一般, 名詞-一般]
+      - [形容詞, 名詞]
+`,
+			want: []string{"Kagome", "で", "赤い蝋燭", "を", "探し", "た"},
+		},
+		{
+			title: "compound with wildcards",
+			config: `filters:
+  - type: compound
+    rules:
+      - ["*-自立", 名詞]
+`,
+			want: []string{"Kagome", "で", "赤い蝋燭", "を", "探し", "た"},
+		},
+		{
+			title: "pos patterns",
+			config: `filters:
+  - type: pos
+    action: keep
+    pos: ["*-自立", "!形容詞"]
+`,
+			want: []string{"探し"},
+		},
+	}
+	for _, v := range testdata {
+		t.Run(v.title, func(t *testing.T) {
+			c, err := filter.LoadChain(strings.NewReader(v.config))
+			if err != nil {
+				t.Fatalf("unexpected error, %v", err)
+			}
+			if got := c.Yield(tokens); !reflect.DeepEqual(got, v.want) {
+				t.Errorf("got %q, want %q", got, v.want)
+			}
+		})
+	}
+}
+
+func TestLoadChain_Error(t *testing.T) {
+	for _, v := range []string{
+		"filters: [",
+		"filters: [{type: piyo}]",
+		"filters: [{type: pos, action: piyo}]",
+		"filters: [{type: compound, rules: [[名詞]]}]",
+		"filters: [{type: compound, rules: [[名詞--一般, 名詞]]}]",
+		"filters: [{type: pos, pos: [名詞--一般]}]",
+	} {
+		if _, err := filter.LoadChain(strings.NewReader(v)); err == nil {
+			t.Errorf("expected error, %q", v)
+		}
+	}
+}
+
+func TestRegisterFilter(t *testing.T) {
+	filter.RegisterFilter("test_truncate", func(c filter.FilterConfig) (filter.TokenFilter, error) {
+		return filter.TokenFilterFunc(func(tokens *[]tokenizer.Token) {
+			*tokens = (*tokens)[:len(c.Words)]
+		}), nil
+	})
+	c, err := filter.LoadChain(strings.NewReader(`filters: [{type: test_truncate, words: [x]}]`))
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tokens := []tokenizer.Token{{Surface: "a"}, {Surface: "b"}}
+	if got, want := c.Yield(tokens), []string{"a"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic")
+		}
+	}()
+	filter.RegisterFilter(filter.POSFilterType, nil)
+}
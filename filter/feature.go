@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"io"
 	"strings"
+
+	"github.com/ikawaha/kagome/v2/tokenizer"
 )
 
 type (
@@ -31,7 +33,7 @@ func (n filterNode) isLeaf() bool {
 
 func (n filterNode) has(s Feature) int {
 	for i, v := range n.fanout {
-		if v.val == Any || v.val == s {
+		if v.val == s {
 			return i
 		}
 	}
@@ -40,7 +42,8 @@ func (n filterNode) has(s Feature) int {
 
 // FeaturesFilter represents a filter that filters a vector of features.
 type FeaturesFilter struct {
-	root filterNode
+	root       filterNode
+	conditions []Condition
 }
 
 // NewFeaturesFilter returns a features filter.
@@ -75,19 +78,40 @@ func (f *FeaturesFilter) add(fs Features) {
 	}
 }
 
+// AddConditions adds the conditions to the filter, e.g.
+// And(Equal(0, "助動詞"), Not(Equal(6, "ない"))). The filter matches if
+// any of the features or the conditions matches.
+func (f *FeaturesFilter) AddConditions(cs ...Condition) {
+	f.conditions = append(f.conditions, cs...)
+}
+
 // Match returns true if a filter matches given features.
 func (f *FeaturesFilter) Match(fs Features) bool {
-	n := &f.root
-	for _, v := range fs {
-		if k := n.has(v); k >= 0 {
-			n = n.fanout[k].to
-			if n.isLeaf() {
-				return true
-			}
-			continue
+	if match(&f.root, fs) {
+		return true
+	}
+	for _, v := range f.conditions {
+		if v.Match(fs) {
+			return true
 		}
+	}
+	return false
+}
+
+// match tries all the edges which match the head of the features, since
+// the edge of Any and the edge of the feature may lead to different conditions.
+func match(n *filterNode, fs Features) bool {
+	if len(fs) == 0 {
 		return false
 	}
+	for _, v := range n.fanout {
+		if v.val != Any && v.val != fs[0] {
+			continue
+		}
+		if v.to.isLeaf() || match(v.to, fs[1:]) {
+			return true
+		}
+	}
 	return false
 }
 
@@ -95,6 +119,9 @@ func (f *FeaturesFilter) Match(fs Features) bool {
 func (f *FeaturesFilter) String() string {
 	var buf strings.Builder
 	filterString(&buf, 0, &f.root)
+	for _, v := range f.conditions {
+		_, _ = fmt.Fprintf(&buf, "%v\n", v)
+	}
 	return buf.String()
 }
 
@@ -107,3 +134,17 @@ func filterString(w io.Writer, indent int, n *filterNode) {
 		}
 	}
 }
+
+// Drop drops a token if a filter matches token's features.
+func (f *FeaturesFilter) Drop(tokens *[]tokenizer.Token) {
+	applyFilter(func(t tokenizer.Token) bool {
+		return f.Match(t.Features())
+	}, tokens, true)
+}
+
+// Keep keeps a token if a filter matches token's features.
+func (f *FeaturesFilter) Keep(tokens *[]tokenizer.Token) {
+	applyFilter(func(t tokenizer.Token) bool {
+		return f.Match(t.Features())
+	}, tokens, false)
+}
@@ -87,3 +87,24 @@ func TestFeaturesFilter_Pass(t *testing.T) {
 		}
 	}
 }
+
+func TestFeaturesFilter_AnyAndFeature(t *testing.T) {
+	f := NewFeaturesFilter(
+		Features{"名詞", Any, "X"},
+		Features{"名詞", "固有名詞", "Y"},
+	)
+	testdata := []struct {
+		features []string
+		want     bool
+	}{
+		{features: []string{"名詞", "固有名詞", "X"}, want: true},
+		{features: []string{"名詞", "固有名詞", "Y"}, want: true},
+		{features: []string{"名詞", "一般", "X"}, want: true},
+		{features: []string{"名詞", "一般", "Y"}, want: false},
+	}
+	for _, v := range testdata {
+		if got := f.Match(v.features); got != v.want {
+			t.Errorf("%+v: want %v, got %v", v.features, v.want, got)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+package ja
+
+import (
+	"strings"
+
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// Chunk represents a bunsetsu, a phrase which consists of content words
+// followed by function words.
+type Chunk struct {
+	Tokens   []tokenizer.Token
+	Position int // byte position
+	Start    int
+	End      int
+	// Head is the index of the last content word in Tokens, or -1.
+	Head int
+	// Function is the index of the last function word in Tokens, or -1.
+	Function int
+}
+
+// Surface returns the surface of the chunk.
+func (c Chunk) Surface() string {
+	var b strings.Builder
+	for _, v := range c.Tokens {
+		b.WriteString(v.Surface)
+	}
+	return b.String()
+}
+
+// Chunker represents a bunsetsu chunker which groups tokens by the
+// part-of-speech rules of a dictionary.
+type Chunker struct {
+	function       *filter.POSFilter
+	suffix         *filter.POSFilter
+	nonIndependent *filter.POSFilter
+	auxiliary      *filter.POSFilter
+	conjunctive    *filter.POSFilter
+	prefix         *filter.POSFilter
+	noun           *filter.POSFilter
+	symbol         *filter.POSFilter
+}
+
+// NewChunker returns a bunsetsu chunker with the part-of-speech rules of
+// the IPA dictionary.
+func NewChunker() *Chunker {
+	return &Chunker{
+		function: filter.NewPOSFilter(
+			filter.POS{"助詞"},
+			filter.POS{"助動詞"},
+		),
+		suffix: filter.NewPOSFilter(
+			filter.POS{"動詞", "非自立"},
+			filter.POS{"動詞", "接尾"},
+			filter.POS{"形容詞", "非自立"},
+			filter.POS{"形容詞", "接尾"},
+			filter.POS{"名詞", "接尾"},
+			filter.POS{"記号", "句点"},
+			filter.POS{"記号", "読点"},
+			filter.POS{"記号", "括弧閉"},
+		),
+		nonIndependent: filter.NewPOSFilter(
+			filter.POS{"名詞", "非自立"},
+		),
+		auxiliary:   filter.NewPOSFilter(),
+		conjunctive: filter.NewPOSFilter(),
+		prefix: filter.NewPOSFilter(
+			filter.POS{"接頭詞"},
+			filter.POS{"記号", "括弧開"},
+		),
+		noun: filter.NewPOSFilter(
+			filter.POS{"名詞"},
+		),
+		symbol: filter.NewPOSFilter(
+			filter.POS{"記号"},
+		),
+	}
+}
+
+// NewUniDicChunker returns a bunsetsu chunker with the part-of-speech rules
+// of the UniDic, e.g. 接頭辞, 接尾辞 and 補助記号. The UniDic does not tell the
+// auxiliary verbs from the others, so a verb or an adjective of 非自立可能
+// attaches to the chunk only after a conjunctive particle, e.g. いる of
+// 棲んでいる.
+func NewUniDicChunker() *Chunker {
+	return &Chunker{
+		function: filter.NewPOSFilter(
+			filter.POS{"助詞"},
+			filter.POS{"助動詞"},
+		),
+		suffix: filter.NewPOSFilter(
+			filter.POS{"接尾辞"},
+			filter.POS{"補助記号", "句点"},
+			filter.POS{"補助記号", "読点"},
+			filter.POS{"補助記号", "括弧閉"},
+		),
+		nonIndependent: filter.NewPOSFilter(),
+		auxiliary: filter.NewPOSFilter(
+			filter.POS{"動詞", "非自立可能"},
+			filter.POS{"形容詞", "非自立可能"},
+		),
+		conjunctive: filter.NewPOSFilter(
+			filter.POS{"助詞", "接続助詞"},
+		),
+		prefix: filter.NewPOSFilter(
+			filter.POS{"接頭辞"},
+			filter.POS{"補助記号", "括弧開"},
+		),
+		noun: filter.NewPOSFilter(
+			filter.POS{"名詞"},
+			filter.POS{"代名詞"},
+		),
+		symbol: filter.NewPOSFilter(
+			filter.POS{"補助記号"},
+			filter.POS{"記号"},
+			filter.POS{"空白"},
+		),
+	}
+}
+
+// Chunk groups the tokens into bunsetsu. A chunk starts at a content word
+// unless the chunk so far has only prefixes, or the content word is a noun
+// which continues a compound noun. Function words, suffixes and punctuations
+// attach to the current chunk. BOS/EOS tokens are ignored.
+func (c Chunker) Chunk(tokens []tokenizer.Token) []Chunk {
+	var (
+		ret []Chunk
+		cur *Chunk
+	)
+	for _, v := range tokens {
+		if v.Class == tokenizer.DUMMY {
+			continue
+		}
+		pos := v.POS()
+		r := c.role(cur, pos)
+		if r == startRole {
+			ret = append(ret, Chunk{
+				Position: v.Position,
+				Start:    v.Start,
+				Head:     -1,
+				Function: -1,
+			})
+			cur = &ret[len(ret)-1]
+			r = contentRole
+			if c.function.Match(pos) {
+				r = functionRole
+			}
+		}
+		switch {
+		case r == functionRole:
+			cur.Function = len(cur.Tokens)
+		case r == contentRole && !c.symbol.Match(pos) && !c.prefix.Match(pos):
+			cur.Head = len(cur.Tokens)
+		}
+		cur.Tokens = append(cur.Tokens, v)
+		cur.End = v.End
+	}
+	return ret
+}
+
+type role int
+
+const (
+	startRole role = iota
+	contentRole
+	functionRole
+	suffixRole
+)
+
+// role returns the role of a token of the POS in the current chunk.
+func (c Chunker) role(cur *Chunk, pos []string) role {
+	if cur == nil {
+		return startRole
+	}
+	last := cur.Tokens[len(cur.Tokens)-1].POS()
+	switch {
+	case c.function.Match(pos):
+		return functionRole
+	case c.suffix.Match(pos):
+		return suffixRole
+	case c.auxiliary.Match(pos) && c.conjunctive.Match(last):
+		return suffixRole
+	case c.nonIndependent.Match(pos):
+		if c.function.Match(last) {
+			return startRole
+		}
+		return suffixRole
+	case cur.Head < 0 && cur.Function < 0:
+		// prefixes only
+		return contentRole
+	case cur.Function < 0 && c.noun.Match(pos) && c.noun.Match(last):
+		return contentRole
+	}
+	return startRole
+}
@@ -0,0 +1,156 @@
+package ja
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/uni"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+func TestChunker_Chunk(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tz, err := tokenizer.New(d)
+	if err != nil {
+		t.Fatal(err)
+	}
+	type chunk struct {
+		surface    string
+		start, end int
+		head, fn   int
+	}
+	testdata := []struct {
+		input string
+		want  []chunk
+	}{
+		{
+			input: "赤い蝋燭と人魚。",
+			want: []chunk{
+				{surface: "赤い", start: 0, end: 2, head: 0, fn: -1},
+				{surface: "蝋燭と", start: 2, end: 5, head: 0, fn: 1},
+				{surface: "人魚。", start: 5, end: 8, head: 0, fn: -1},
+			},
+		},
+		{
+			input: "南の方の海に棲んでいるのではありません。",
+			want: []chunk{
+				{surface: "南の", start: 0, end: 2, head: 0, fn: 1},
+				{surface: "方の", start: 2, end: 4, head: 0, fn: 1},
+				{surface: "海に", start: 4, end: 6, head: 0, fn: 1},
+				{surface: "棲んでいるのでは", start: 6, end: 14, head: 0, fn: 5},
+				{surface: "ありません。", start: 14, end: 20, head: 0, fn: 2},
+			},
+		},
+		{
+			input: "「お茶」を3杯飲んだ",
+			want: []chunk{
+				{surface: "「お茶」を", start: 0, end: 5, head: 1, fn: 3},
+				{surface: "3杯", start: 5, end: 7, head: 0, fn: -1},
+				{surface: "飲んだ", start: 7, end: 10, head: 0, fn: 1},
+			},
+		},
+		{
+			input: "情報処理技術者試験をお受けになる",
+			want: []chunk{
+				{surface: "情報処理技術者試験を", start: 0, end: 10, head: 3, fn: 4},
+				{surface: "お受けに", start: 10, end: 14, head: 1, fn: 2},
+				{surface: "なる", start: 14, end: 16, head: 0, fn: -1},
+			},
+		},
+	}
+	for _, v := range testdata {
+		t.Run(v.input, func(t *testing.T) {
+			var got []chunk
+			for _, c := range NewChunker().Chunk(tz.Tokenize(v.input)) {
+				got = append(got, chunk{
+					surface: c.Surface(),
+					start:   c.Start,
+					end:     c.End,
+					head:    c.Head,
+					fn:      c.Function,
+				})
+			}
+			if !reflect.DeepEqual(got, v.want) {
+				t.Errorf("got %+v, want %+v", got, v.want)
+			}
+		})
+	}
+	t.Run("byte position", func(t *testing.T) {
+		chunks := NewChunker().Chunk(tz.Tokenize("赤い蝋燭と人魚。"))
+		if got, want := chunks[1].Position, len("赤い"); got != want {
+			t.Errorf("got %d, want %d", got, want)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		if got := NewChunker().Chunk(nil); got != nil {
+			t.Errorf("got %+v, want nil", got)
+		}
+	})
+}
+
+func TestUniDicChunker_Chunk(t *testing.T) {
+	tz, err := tokenizer.New(uni.Dict())
+	if err != nil {
+		t.Fatal(err)
+	}
+	type chunk struct {
+		surface  string
+		head, fn int
+	}
+	testdata := []struct {
+		input string
+		want  []chunk
+	}{
+		{
+			input: "赤い蝋燭と人魚。",
+			want: []chunk{
+				{surface: "赤い", head: 0, fn: -1},
+				{surface: "蝋燭と", head: 0, fn: 1},
+				{surface: "人魚。", head: 0, fn: -1},
+			},
+		},
+		{
+			input: "お茶を飲みたい。",
+			want: []chunk{
+				{surface: "お茶を", head: 1, fn: 2},
+				{surface: "飲みたい。", head: 0, fn: 1},
+			},
+		},
+		{
+			input: "南の海に棲んでいるのではありません。",
+			want: []chunk{
+				{surface: "南の", head: 0, fn: 1},
+				{surface: "海に", head: 0, fn: 1},
+				{surface: "棲んでいるのでは", head: 0, fn: 5},
+				{surface: "ありません。", head: 0, fn: 2},
+			},
+		},
+		{
+			input: "「お茶」を3杯飲んだ",
+			want: []chunk{
+				{surface: "「お茶」を", head: 2, fn: 4},
+				{surface: "3杯", head: 1, fn: -1},
+				{surface: "飲んだ", head: 0, fn: 1},
+			},
+		},
+	}
+	for _, v := range testdata {
+		t.Run(v.input, func(t *testing.T) {
+			var got []chunk
+			for _, c := range NewUniDicChunker().Chunk(tz.Tokenize(v.input)) {
+				got = append(got, chunk{
+					surface: c.Surface(),
+					head:    c.Head,
+					fn:      c.Function,
+				})
+			}
+			if !reflect.DeepEqual(got, v.want) {
+				t.Errorf("got %+v, want %+v", got, v.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package ja
+
+import (
+	"github.com/ikawaha/kagome/v2/filter"
+)
+
+// CompoundFilterType is the type of the compound filter with the default
+// rules in a chain configuration.
+const CompoundFilterType = "ja_compound"
+
+var (
+	compoundNouns = []filter.POS{
+		{"名詞", "一般"},
+		{"名詞", "固有名詞"},
+		{"名詞", "サ変接続"},
+		{"名詞", "形容動詞語幹"},
+		{"名詞", "ナイ形容詞語幹"},
+	}
+	compoundSuffix  = filter.POS{"名詞", "接尾"}
+	compoundPrefix  = filter.POS{"接頭詞", "名詞接続"}
+	compoundNumber  = filter.POS{"名詞", "数"}
+	compoundCounter = filter.POS{"名詞", "接尾", "助数詞"}
+)
+
+// DefaultCompoundRules returns the rules which join compound nouns of the
+// IPA dictionary: noun + noun, noun + suffix, suffix + noun, prefix + noun
+// and number + counter, e.g. 情報処理/技術/者/試験 and 3/杯.
+func DefaultCompoundRules() []filter.CompoundRule {
+	var ret []filter.CompoundRule
+	for _, l := range compoundNouns {
+		for _, r := range compoundNouns {
+			ret = append(ret, filter.CompoundRule{Left: l, Right: r})
+		}
+		ret = append(ret,
+			filter.CompoundRule{Left: l, Right: compoundSuffix},
+			filter.CompoundRule{Left: compoundSuffix, Right: l},
+			filter.CompoundRule{Left: compoundPrefix, Right: l},
+		)
+	}
+	return append(ret, filter.CompoundRule{Left: compoundNumber, Right: compoundCounter})
+}
+
+// NewCompoundFilter returns a compound filter with the default rules.
+func NewCompoundFilter() *filter.CompoundFilter {
+	return filter.NewCompoundFilter(DefaultCompoundRules()...)
+}
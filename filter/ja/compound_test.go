@@ -0,0 +1,49 @@
+package ja
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+func TestCompoundFilter(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testdata := []struct {
+		input string
+		want  []string
+	}{
+		{input: "情報処理技術者試験を受ける", want: []string{"情報処理技術者試験", "を", "受ける"}},
+		{input: "お茶を3杯飲んだ", want: []string{"お茶", "を", "3杯", "飲ん", "だ"}},
+		{input: "東京都庁の全面改装", want: []string{"東京都庁", "の", "全面改装"}},
+		{input: "人魚は南の方の海", want: []string{"人魚", "は", "南", "の", "方", "の", "海"}},
+	}
+	for _, v := range testdata {
+		t.Run(v.input, func(t *testing.T) {
+			tokens := tz.Tokenize(v.input)
+			NewCompoundFilter().Apply(&tokens)
+			if got := surfaces(tokens); !reflect.DeepEqual(got, v.want) {
+				t.Errorf("got %q, want %q", got, v.want)
+			}
+		})
+	}
+	t.Run("chain config", func(t *testing.T) {
+		c, err := filter.LoadChain(strings.NewReader("filters: [{type: ja_compound}]"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := c.Yield(tz.Tokenize("3杯")), []string{"3杯"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+}
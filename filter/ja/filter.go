@@ -33,6 +33,19 @@ func StopTagsFilterOption(p []filter.POS) FilterOption {
 	}
 }
 
+// StopTagsPatternFilterOption returns a stop tags filter option described by
+// the patterns of filter.NewPOSFilterFromPatterns, e.g. "名詞-固有名詞-*" or
+// "動詞-*,!動詞-自立".
+func StopTagsPatternFilterOption(patterns ...string) (FilterOption, error) {
+	p, err := filter.NewPOSFilterFromPatterns(patterns...)
+	if err != nil {
+		return nil, err
+	}
+	return func(f *Filter) {
+		f.stopTags = p
+	}, nil
+}
+
 // StopWordsFilterOption returns a stop words filter option.
 func StopWordsFilterOption(p []string) FilterOption {
 	return func(f *Filter) {
@@ -53,9 +66,26 @@ func NewFilter(opts ...FilterOption) (*Filter, error) {
 	return ret, err
 }
 
+// NewUniDicFilter returns a filter with the settings of NewFilter for
+// the UniDic, whose stop tags and parts-of-speech of the base forms follow
+// the part-of-speech system of the UniDic, e.g. 補助記号 and 形状詞.
+func NewUniDicFilter(opts ...FilterOption) (*Filter, error) {
+	ret, err := newDefaultFilter(uniStopTags, uniBaseFormPOS)
+	if err != nil {
+		return nil, err
+	}
+	for _, opt := range opts {
+		opt(ret)
+	}
+	return ret, err
+}
+
 //go:embed asset/stop_tags.txt
 var stopTags []byte
 
+//go:embed asset/stop_tags_uni.txt
+var uniStopTags []byte
+
 //go:embed asset/stop_words.txt
 var stropWords []byte
 
@@ -64,8 +94,17 @@ const (
 	defaultPOSFeature = "*"
 )
 
+var (
+	baseFormPOS    = []filter.POS{{"動詞"}, {"形容詞"}, {"形容動詞"}}
+	uniBaseFormPOS = []filter.POS{{"動詞"}, {"形容詞"}, {"形状詞"}}
+)
+
 func newDefaultLuceneFilter() (*Filter, error) {
-	ta, err := newDefaultLuceneStopTagPOSFilter()
+	return newDefaultFilter(stopTags, baseFormPOS)
+}
+
+func newDefaultFilter(tags []byte, baseForm []filter.POS) (*Filter, error) {
+	ta, err := newDefaultStopTagPOSFilter(tags)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load stop tags: %w", err)
 	}
@@ -74,26 +113,32 @@ func newDefaultLuceneFilter() (*Filter, error) {
 		return nil, fmt.Errorf("failed to load stop words: %w", err)
 	}
 	return &Filter{
-		baserForm: filter.NewPOSFilter(filter.POS{"動詞"}, filter.POS{"形容詞"}, filter.POS{"形容動詞"}),
+		baserForm: filter.NewPOSFilter(baseForm...),
 		stopTags:  ta,
 		stopWords: wo,
 	}, nil
 }
 
-func newDefaultLuceneStopTagPOSFilter() (*filter.POSFilter, error) {
-	t, err := loadConfig(stopTags)
+func newDefaultStopTagPOSFilter(tags []byte) (*filter.POSFilter, error) {
+	t, err := loadConfig(tags)
 	if err != nil {
 		return nil, fmt.Errorf("failed to load stop tags: %w", err)
 	}
-	ps := make([]filter.POS, 0, len(t))
-	for _, v := range t {
+	return filter.NewPOSFilter(parseStopTags(t)...), nil
+}
+
+// parseStopTags parses the stop tags described as the features joined with
+// a hyphen and fills the omitted features with "*".
+func parseStopTags(tags []string) []filter.POS {
+	ps := make([]filter.POS, 0, len(tags))
+	for _, v := range tags {
 		pos := strings.Split(v, "-")
 		for i := len(pos); i < posHierarchy; i++ {
 			pos = append(pos, defaultPOSFeature)
 		}
 		ps = append(ps, pos)
 	}
-	return filter.NewPOSFilter(ps...), nil
+	return ps
 }
 
 func newDefaultLuceneStopWordFilter() (*filter.WordFilter, error) {
@@ -104,6 +149,65 @@ func newDefaultLuceneStopWordFilter() (*filter.WordFilter, error) {
 	return filter.NewWordFilter(t), nil
 }
 
+// FilterType is the type of the japanese filter in a chain configuration
+// (see filter.ChainConfig). The pos or pos_patterns, and the words of the
+// configuration replace the default stop tags and stop words. The pos are
+// the stop tags in the same format as ReadStopTags, which exactly match the
+// POS of tokens, and the pos_patterns are the stop tags described by the
+// patterns of StopTagsPatternFilterOption. The filter drops the matched
+// tokens.
+const FilterType = "ja"
+
+// UniDicFilterType is the type of the japanese filter for the UniDic
+// (see NewUniDicFilter) in a chain configuration.
+const UniDicFilterType = "ja_uni"
+
+func init() {
+	filter.RegisterFilter(FilterType, func(c filter.FilterConfig) (filter.TokenFilter, error) {
+		return newTokenFilter(c, NewFilter)
+	})
+	filter.RegisterFilter(UniDicFilterType, func(c filter.FilterConfig) (filter.TokenFilter, error) {
+		return newTokenFilter(c, NewUniDicFilter)
+	})
+	filter.RegisterFilter(NumberFilterType, func(filter.FilterConfig) (filter.TokenFilter, error) {
+		return NewNumberFilter(), nil
+	})
+	filter.RegisterFilter(KatakanaStemFilterType, func(filter.FilterConfig) (filter.TokenFilter, error) {
+		return NewKatakanaStemFilter(DefaultMinimumKatakanaLength), nil
+	})
+	filter.RegisterFilter(CompoundFilterType, func(filter.FilterConfig) (filter.TokenFilter, error) {
+		return NewCompoundFilter(), nil
+	})
+}
+
+func newTokenFilter(c filter.FilterConfig, newFilter func(...FilterOption) (*Filter, error)) (filter.TokenFilter, error) {
+	if c.Action != "" && c.Action != filter.DropAction {
+		return nil, fmt.Errorf("unsupported action, %q", c.Action)
+	}
+	if len(c.POS) > 0 && len(c.POSPatterns) > 0 {
+		return nil, fmt.Errorf("pos and pos_patterns are exclusive")
+	}
+	var opts []FilterOption
+	if len(c.POS) > 0 {
+		opts = append(opts, StopTagsFilterOption(parseStopTags(c.POS)))
+	}
+	if len(c.POSPatterns) > 0 {
+		opt, err := StopTagsPatternFilterOption(c.POSPatterns...)
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, opt)
+	}
+	if len(c.Words) > 0 {
+		opts = append(opts, StopWordsFilterOption(c.Words))
+	}
+	f, err := newFilter(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return filter.TokenFilterFunc(f.Drop), nil
+}
+
 // Yield returns a filtered word sequence from a token sequence.
 func (f Filter) Yield(tokens []tokenizer.Token) []string {
 	var ret []string
@@ -2,9 +2,12 @@ package ja
 
 import (
 	"reflect"
+	"strings"
 	"testing"
 
 	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/uni"
+	"github.com/ikawaha/kagome/v2/filter"
 	"github.com/ikawaha/kagome/v2/tokenizer"
 )
 
@@ -57,3 +60,136 @@ func TestFilter(t *testing.T) {
 		}
 	})
 }
+
+func TestStopTagsPatternFilterOption(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := tz.Tokenize("人魚は、南の方の海にばかり棲んでいるのではありません。")
+	opt, err := StopTagsPatternFilterOption("助詞-*,助動詞,記号", "動詞-*,!動詞-自立")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := NewFilter(opt, StopWordsFilterOption(nil))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"人魚", "南", "方", "海", "棲む", "の", "ある"}
+	if got := f.Yield(tokens); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+	if _, err := StopTagsPatternFilterOption("!"); err == nil {
+		t.Error("expected error")
+	}
+}
+
+func TestChainConfig(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := tz.Tokenize("人魚は、南の方の海にばかり棲んでいるのではありません。")
+	t.Run("default stop tags and words", func(t *testing.T) {
+		c, err := filter.LoadChain(strings.NewReader("filters: [{type: ja}, {type: base_form, pos: [動詞]}]"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"人魚", "南", "方", "海", "棲む"}
+		if got := c.Yield(tokens); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+	t.Run("custom stop tags and words", func(t *testing.T) {
+		c, err := filter.LoadChain(strings.NewReader("filters: [{type: ja, pos: [名詞-一般], words: [方]}]"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"は", "、", "の", "の", "に", "ばかり", "棲ん", "で", "いる", "の", "で", "は", "あり", "ませ", "ん", "。"}
+		if got := c.Yield(tokens); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+	t.Run("stop tags exactly match", func(t *testing.T) {
+		// 名詞 matches 名詞-*-*-* only, not 名詞-一般 nor 名詞-非自立.
+		c, err := filter.LoadChain(strings.NewReader(`filters: [{type: ja, pos: [名詞, 助詞-連体化], words: [ん]}]`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"人魚", "は", "、", "南", "方", "海", "に", "ばかり", "棲ん", "で", "いる", "の", "で", "は", "あり", "ませ", "。"}
+		if got := c.Yield(tokens); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+	t.Run("stop tag patterns", func(t *testing.T) {
+		c, err := filter.LoadChain(strings.NewReader(`filters: [{type: ja, pos_patterns: ["名詞,!名詞-非自立", 助詞-*], words: [ん]}]`))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"、", "方", "棲ん", "いる", "の", "で", "あり", "ませ", "。"}
+		if got := c.Yield(tokens); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+	t.Run("pos and pos_patterns", func(t *testing.T) {
+		if _, err := filter.LoadChain(strings.NewReader("filters: [{type: ja, pos: [名詞], pos_patterns: [名詞]}]")); err == nil {
+			t.Error("expected error")
+		}
+	})
+	t.Run("invalid stop tag pattern", func(t *testing.T) {
+		if _, err := filter.LoadChain(strings.NewReader("filters: [{type: ja, pos_patterns: [名詞--一般]}]")); err == nil {
+			t.Error("expected error")
+		}
+	})
+	t.Run("unsupported action", func(t *testing.T) {
+		if _, err := filter.LoadChain(strings.NewReader("filters: [{type: ja, action: keep}]")); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestNewUniDicFilter(t *testing.T) {
+	tz, err := tokenizer.New(uni.Dict(), tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := tz.Tokenize("静かな部屋で「えっと」と言った人魚は、南の海に棲んでいるのではありません。")
+	t.Run("yield string from tokens", func(t *testing.T) {
+		f, err := NewUniDicFilter()
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"静か", "部屋", "言う", "人魚", "南", "海", "棲む"}
+		if got := f.Yield(tokens); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+	t.Run("options", func(t *testing.T) {
+		f, err := NewUniDicFilter(StopWordsFilterOption([]string{"人魚"}))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"静か", "部屋", "言う", "南", "海", "棲む", "いる", "ある"}
+		if got := f.Yield(tokens); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+	t.Run("chain config", func(t *testing.T) {
+		c, err := filter.LoadChain(strings.NewReader("filters: [{type: ja_uni}]"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := []string{"静か", "部屋", "言っ", "人魚", "南", "海", "棲ん"}
+		if got := c.Yield(tokens); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %+v, want %+v", got, want)
+		}
+	})
+}
@@ -0,0 +1,83 @@
+package ja
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+const (
+	// KatakanaStemFilterType is the type of the katakana stem filter in a chain configuration.
+	KatakanaStemFilterType = "ja_katakana_stem"
+	// DefaultMinimumKatakanaLength is the default minimum length of a katakana word to stem.
+	DefaultMinimumKatakanaLength = 4
+
+	prolongedSoundMark = 'ー'
+)
+
+// vuReplacer unifies the ヴ variants into the バ行 ones.
+var vuReplacer = strings.NewReplacer(
+	"ヴァ", "バ",
+	"ヴィ", "ビ",
+	"ヴェ", "ベ",
+	"ヴォ", "ボ",
+	"ヴュ", "ビュ",
+	"ヴ", "ブ",
+)
+
+// KatakanaStemFilter represents a filter which normalizes katakana words like
+// the JapaneseKatakanaStemFilter of lucene. It strips a trailing prolonged
+// sound mark from a katakana word of the minimum length or longer, e.g.
+// コンピューター to コンピュータ, and unifies the ヴ variants, e.g. ヴァイオリン
+// to バイオリン.
+type KatakanaStemFilter struct {
+	minLength int
+}
+
+// NewKatakanaStemFilter returns a katakana stem filter. If minLength <= 0,
+// DefaultMinimumKatakanaLength is used.
+func NewKatakanaStemFilter(minLength int) *KatakanaStemFilter {
+	if minLength <= 0 {
+		minLength = DefaultMinimumKatakanaLength
+	}
+	return &KatakanaStemFilter{
+		minLength: minLength,
+	}
+}
+
+// Apply normalizes the katakana words in the tokens.
+func (f KatakanaStemFilter) Apply(tokens *[]tokenizer.Token) {
+	if tokens == nil {
+		return
+	}
+	for i, v := range *tokens {
+		(*tokens)[i].Surface = f.Stem(v.Surface)
+	}
+}
+
+// Stem returns the normalized form of a katakana word. It returns the input
+// as it is if the input is not a katakana word.
+func (f KatakanaStemFilter) Stem(s string) string {
+	if !isKatakana(s) {
+		return s
+	}
+	s = vuReplacer.Replace(s)
+	if utf8.RuneCountInString(s) >= f.minLength && strings.HasSuffix(s, string(prolongedSoundMark)) {
+		s = s[:len(s)-utf8.RuneLen(prolongedSoundMark)]
+	}
+	return s
+}
+
+func isKatakana(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if r != prolongedSoundMark && !unicode.Is(unicode.Katakana, r) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,75 @@
+package ja
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+func TestKatakanaStemFilter_Stem(t *testing.T) {
+	testdata := []struct {
+		minLength int
+		input     string
+		want      string
+	}{
+		{input: "コンピューター", want: "コンピュータ"},
+		{input: "コンピュータ", want: "コンピュータ"},
+		{input: "サーバー", want: "サーバ"},
+		{input: "カバー", want: "カバー"}, // too short
+		{input: "ヴァイオリン", want: "バイオリン"},
+		{input: "ヴィーナス", want: "ビーナス"},
+		{input: "ヴェール", want: "ベール"},
+		{input: "ヴォーカル", want: "ボーカル"},
+		{input: "ヴュー", want: "ビュー"},
+		{input: "ヴ", want: "ブ"},
+		{input: "ユーザーー", want: "ユーザー"},  // strips only one mark
+		{input: "ゲーム機ー", want: "ゲーム機ー"}, // not a katakana word
+		{input: "らーめん", want: "らーめん"},
+		{minLength: 2, input: "カバー", want: "カバ"},
+	}
+	for _, v := range testdata {
+		t.Run(v.input, func(t *testing.T) {
+			if got := NewKatakanaStemFilter(v.minLength).Stem(v.input); got != v.want {
+				t.Errorf("got %q, want %q", got, v.want)
+			}
+		})
+	}
+}
+
+func TestKatakanaStemFilter_Apply(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatal(err)
+	}
+	a := tz.Tokenize("コンピューターのサーバー")
+	b := tz.Tokenize("コンピュータのサーバ")
+	f := NewKatakanaStemFilter(DefaultMinimumKatakanaLength)
+	f.Apply(&a)
+	f.Apply(&b)
+	if !reflect.DeepEqual(surfaces(a), surfaces(b)) {
+		t.Errorf("got %q and %q, want the same", surfaces(a), surfaces(b))
+	}
+	c, err := filter.LoadChain(strings.NewReader("filters: [{type: ja_katakana_stem}]"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := c.Yield(tz.Tokenize("ヴァイオリン")), []string{"バイオリン"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func surfaces(tokens []tokenizer.Token) []string {
+	ret := make([]string, 0, len(tokens))
+	for _, v := range tokens {
+		ret = append(ret, v.Surface)
+	}
+	return ret
+}
@@ -3,11 +3,81 @@ package ja
 import (
 	"bufio"
 	"bytes"
+	"fmt"
+	"io"
+	"os"
 	"strings"
+
+	"github.com/ikawaha/kagome/v2/filter"
 )
 
+// ReadStopWords reads a stop word list, one word per line. A line which
+// starts with "#" is a comment, and "#" starts a comment in the middle of
+// a line as well.
+func ReadStopWords(r io.Reader) ([]string, error) {
+	return readConfig(r)
+}
+
+// ReadStopTags reads a stop tag list, one part-of-speech per line, in the
+// format of the stop word list. A part-of-speech is described as the
+// features joined with a hyphen, and the omitted features are filled
+// with "*", e.g. "名詞-数" matches "名詞,数,*,*".
+func ReadStopTags(r io.Reader) ([]filter.POS, error) {
+	t, err := readConfig(r)
+	if err != nil {
+		return nil, err
+	}
+	return parseStopTags(t), nil
+}
+
+// StopWordsFilterOptionFromReader returns a stop words filter option which
+// reads the stop words from the reader (see ReadStopWords).
+func StopWordsFilterOptionFromReader(r io.Reader) (FilterOption, error) {
+	w, err := ReadStopWords(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load stop words: %w", err)
+	}
+	return StopWordsFilterOption(w), nil
+}
+
+// StopWordsFilterOptionFromFile returns a stop words filter option which
+// reads the stop words from the file (see ReadStopWords).
+func StopWordsFilterOptionFromFile(path string) (FilterOption, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return StopWordsFilterOptionFromReader(f)
+}
+
+// StopTagsFilterOptionFromReader returns a stop tags filter option which
+// reads the stop tags from the reader (see ReadStopTags).
+func StopTagsFilterOptionFromReader(r io.Reader) (FilterOption, error) {
+	p, err := ReadStopTags(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load stop tags: %w", err)
+	}
+	return StopTagsFilterOption(p), nil
+}
+
+// StopTagsFilterOptionFromFile returns a stop tags filter option which
+// reads the stop tags from the file (see ReadStopTags).
+func StopTagsFilterOptionFromFile(path string) (FilterOption, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return StopTagsFilterOptionFromReader(f)
+}
+
 func loadConfig(b []byte) ([]string, error) {
-	s := bufio.NewScanner(bytes.NewReader(b))
+	return readConfig(bytes.NewReader(b))
+}
+
+func readConfig(r io.Reader) ([]string, error) {
+	s := bufio.NewScanner(r)
 	var ret []string
 	for s.Scan() {
 		line := s.Text()
@@ -17,6 +87,7 @@ func loadConfig(b []byte) ([]string, error) {
 		if i := strings.Index(line, "#"); i > 0 {
 			line = line[:i]
 		}
+		line = strings.TrimSpace(line)
 		if line == "" {
 			continue
 		}
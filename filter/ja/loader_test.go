@@ -0,0 +1,94 @@
+package ja
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+func TestReadStopWords(t *testing.T) {
+	const input = `# stop words
+は
+の # particle
+
+  に  
+`
+	got, err := ReadStopWords(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if want := []string{"は", "の", "に"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestReadStopTags(t *testing.T) {
+	const input = `#
+# stop tags
+#名詞
+助詞-係助詞
+記号
+`
+	got, err := ReadStopTags(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	want := []filter.POS{
+		{"助詞", "係助詞", "*", "*"},
+		{"記号", "*", "*", "*"},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %q, want %q", got, want)
+	}
+}
+
+func TestFilterOptionFromFile(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatal(err)
+	}
+	tokens := tz.Tokenize("人魚は、南の方の海にばかり棲んでいるのではありません。")
+
+	dir := t.TempDir()
+	words := filepath.Join(dir, "stop_words.txt")
+	if err := os.WriteFile(words, []byte("# stop words\n南\n方\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	tags := filepath.Join(dir, "stop_tags.txt")
+	if err := os.WriteFile(tags, []byte("# stop tags\n助詞-係助詞\n助詞-連体化\n助詞-格助詞-一般\n助詞-副助詞\n助詞-接続助詞\n助動詞\n動詞-非自立\n名詞-非自立-一般\n記号-読点\n記号-句点\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	wo, err := StopWordsFilterOptionFromFile(words)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	to, err := StopTagsFilterOptionFromFile(tags)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	f, err := NewFilter(wo, to)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"人魚", "海", "棲む", "ある"}
+	if got := f.Yield(tokens); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+
+	if _, err := StopWordsFilterOptionFromFile(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("expected error")
+	}
+	if _, err := StopTagsFilterOptionFromFile(filepath.Join(dir, "missing.txt")); err == nil {
+		t.Error("expected error")
+	}
+}
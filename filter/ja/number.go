@@ -0,0 +1,353 @@
+package ja
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// NumberFilterType is the type of the number filter in a chain configuration.
+const NumberFilterType = "ja_number"
+
+// NumberFilter represents a filter which joins the tokens of a japanese
+// number expression, e.g. 二千十五, 3万5千, １．２万, and replaces them with
+// a single token whose surface is the normalized number in arabic digits.
+// Only the tokens of the number part-of-speech (名詞-数 of IPA, 名詞-数詞 of
+// UniDic) and the separators between them are joined, e.g. the adverb 万一
+// is left as it is. The merged token is a synthetic token which has the
+// part-of-speech of the first token and the normalized number as the base
+// form, and no reading.
+type NumberFilter struct{}
+
+// NewNumberFilter returns a number filter.
+func NewNumberFilter() *NumberFilter {
+	return &NumberFilter{}
+}
+
+// Apply normalizes the number expressions in the tokens.
+func (f NumberFilter) Apply(tokens *[]tokenizer.Token) {
+	if tokens == nil {
+		return
+	}
+	ts := *tokens
+	tail := 0
+	for i := 0; i < len(ts); {
+		j := numberRunEnd(ts, i)
+		if j == i {
+			ts[tail] = ts[i]
+			tail++
+			i++
+			continue
+		}
+		var b strings.Builder
+		for _, v := range ts[i:j] {
+			b.WriteString(v.Surface)
+		}
+		n, ok := NormalizeNumber(b.String())
+		if !ok {
+			for _, v := range ts[i:j] {
+				ts[tail] = v
+				tail++
+			}
+			i = j
+			continue
+		}
+		t := tokenizer.NewSyntheticToken(n, tokenizer.SyntheticFeatures{
+			POS:      ts[i].POS(),
+			BaseForm: n,
+		})
+		t.Index = ts[i].Index
+		t.Position = ts[i].Position
+		t.Start = ts[i].Start
+		t.End = ts[j-1].End
+		ts[tail] = t
+		tail++
+		i = j
+	}
+	*tokens = ts[:tail]
+}
+
+// numberRunEnd returns the end of the tokens of a number expression which
+// starts at i. It returns i if the token at i does not start a number.
+func numberRunEnd(tokens []tokenizer.Token, i int) int {
+	j, digit := i, false
+	for j < len(tokens) {
+		s := tokens[j].Surface
+		if !isNumberString(s) {
+			break
+		}
+		if isSeparatorString(s) {
+			// a separator must be between numbers.
+			if j == i || j+1 >= len(tokens) || !isNumberToken(tokens[j+1]) || isSeparatorString(tokens[j+1].Surface) {
+				break
+			}
+		} else if !isNumberPOS(tokens[j].POS()) {
+			break
+		}
+		for _, r := range s {
+			if _, ok := digitValue(r); ok {
+				digit = true
+				break
+			}
+			if _, ok := mediumUnits[r]; ok {
+				digit = true
+				break
+			}
+		}
+		j++
+	}
+	if !digit {
+		return i
+	}
+	return j
+}
+
+func isNumberToken(t tokenizer.Token) bool {
+	return isNumberString(t.Surface) && isNumberPOS(t.POS())
+}
+
+// isNumberPOS reports whether the part-of-speech is 名詞-数 (IPA) or
+// 名詞-数詞 (UniDic).
+func isNumberPOS(pos []string) bool {
+	return len(pos) >= 2 && pos[0] == "名詞" && (pos[1] == "数" || pos[1] == "数詞")
+}
+
+func isNumberString(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !isNumberRune(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isSeparatorString(s string) bool {
+	for _, r := range s {
+		if !isDecimalPoint(r) && !isThousandSeparator(r) {
+			return false
+		}
+	}
+	return true
+}
+
+func isNumberRune(r rune) bool {
+	if _, ok := digitValue(r); ok {
+		return true
+	}
+	if _, ok := mediumUnits[r]; ok {
+		return true
+	}
+	if _, ok := largeUnits[r]; ok {
+		return true
+	}
+	return isDecimalPoint(r) || isThousandSeparator(r)
+}
+
+func digitValue(r rune) (int64, bool) {
+	switch {
+	case r >= '0' && r <= '9':
+		return int64(r - '0'), true
+	case r >= '０' && r <= '９':
+		return int64(r - '０'), true
+	}
+	switch r {
+	case '〇', '零':
+		return 0, true
+	case '一':
+		return 1, true
+	case '二':
+		return 2, true
+	case '三':
+		return 3, true
+	case '四':
+		return 4, true
+	case '五':
+		return 5, true
+	case '六':
+		return 6, true
+	case '七':
+		return 7, true
+	case '八':
+		return 8, true
+	case '九':
+		return 9, true
+	}
+	return 0, false
+}
+
+func isDecimalPoint(r rune) bool {
+	return r == '.' || r == '．'
+}
+
+func isThousandSeparator(r rune) bool {
+	return r == ',' || r == '，'
+}
+
+var (
+	mediumUnits = map[rune]int64{
+		'十': 10,
+		'百': 100,
+		'千': 1000,
+	}
+	largeUnits = map[rune]*big.Int{
+		'万': new(big.Int).Exp(big.NewInt(10), big.NewInt(4), nil),
+		'億': new(big.Int).Exp(big.NewInt(10), big.NewInt(8), nil),
+		'兆': new(big.Int).Exp(big.NewInt(10), big.NewInt(12), nil),
+		'京': new(big.Int).Exp(big.NewInt(10), big.NewInt(16), nil),
+	}
+)
+
+// NormalizeNumber converts a japanese number expression into arabic digits,
+// e.g. 二千十五 to 2015, 3万5千 to 35000 and １．２万 to 12000.
+// It returns false if the expression is not a number.
+func NormalizeNumber(s string) (string, bool) {
+	p := numberParser{runes: []rune(s)}
+	n, ok := p.parse()
+	if !ok {
+		return "", false
+	}
+	if n.IsInt() {
+		return n.Num().String(), true
+	}
+	ret := n.FloatString(p.decimals)
+	ret = strings.TrimRight(ret, "0")
+	return strings.TrimSuffix(ret, "."), true
+}
+
+type numberParser struct {
+	runes    []rune
+	pos      int
+	decimals int // the maximum number of decimal places
+}
+
+func (p *numberParser) parse() (*big.Rat, bool) {
+	total := new(big.Rat)
+	var last *big.Int
+	for p.pos < len(p.runes) {
+		n, ok := p.parseMedium()
+		if !ok {
+			return nil, false
+		}
+		if p.pos < len(p.runes) {
+			if unit, ok := largeUnits[p.runes[p.pos]]; ok {
+				if last != nil && unit.Cmp(last) >= 0 {
+					return nil, false
+				}
+				if n == nil {
+					n = big.NewRat(1, 1)
+				}
+				total.Add(total, n.Mul(n, new(big.Rat).SetInt(unit)))
+				last = unit
+				p.pos++
+				continue
+			}
+		}
+		if n == nil || p.pos < len(p.runes) {
+			return nil, false
+		}
+		total.Add(total, n)
+	}
+	if len(p.runes) == 0 {
+		return nil, false
+	}
+	return total, true
+}
+
+// parseMedium parses a number less than 10000 such as 二千十五 or 5千.
+// It returns nil if there is no number.
+func (p *numberParser) parseMedium() (*big.Rat, bool) {
+	var (
+		ret  *big.Rat
+		last int64
+	)
+	for p.pos < len(p.runes) {
+		n, ok := p.parseBasic()
+		if !ok {
+			return nil, false
+		}
+		if p.pos < len(p.runes) {
+			if unit, ok := mediumUnits[p.runes[p.pos]]; ok {
+				if last != 0 && unit >= last {
+					return nil, false
+				}
+				if n == nil {
+					n = big.NewRat(1, 1)
+				}
+				if ret == nil {
+					ret = new(big.Rat)
+				}
+				ret.Add(ret, n.Mul(n, big.NewRat(unit, 1)))
+				last = unit
+				p.pos++
+				continue
+			}
+		}
+		if n != nil {
+			if ret == nil {
+				ret = new(big.Rat)
+			}
+			ret.Add(ret, n)
+		}
+		break
+	}
+	return ret, true
+}
+
+// parseBasic parses a positional number such as 二〇一五, 3,000 or 1.5.
+// It returns nil if there is no number.
+func (p *numberParser) parseBasic() (*big.Rat, bool) {
+	var (
+		b       strings.Builder
+		digits  bool
+		decimal bool
+		places  int
+	)
+	for p.pos < len(p.runes) {
+		r := p.runes[p.pos]
+		if d, ok := digitValue(r); ok {
+			b.WriteByte(byte('0' + d))
+			digits = true
+			if decimal {
+				places++
+			}
+			p.pos++
+			continue
+		}
+		if isThousandSeparator(r) && digits && !decimal && p.nextIsDigit() {
+			p.pos++
+			continue
+		}
+		if isDecimalPoint(r) && digits && !decimal && p.nextIsDigit() {
+			b.WriteByte('.')
+			decimal = true
+			p.pos++
+			continue
+		}
+		break
+	}
+	if !digits {
+		if p.pos < len(p.runes) {
+			if r := p.runes[p.pos]; isDecimalPoint(r) || isThousandSeparator(r) {
+				return nil, false
+			}
+		}
+		return nil, true
+	}
+	if places > p.decimals {
+		p.decimals = places
+	}
+	ret, ok := new(big.Rat).SetString(b.String())
+	return ret, ok
+}
+
+func (p *numberParser) nextIsDigit() bool {
+	if p.pos+1 >= len(p.runes) {
+		return false
+	}
+	_, ok := digitValue(p.runes[p.pos+1])
+	return ok
+}
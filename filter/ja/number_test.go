@@ -0,0 +1,134 @@
+package ja
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+func TestNormalizeNumber(t *testing.T) {
+	testdata := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{input: "二千十五", want: "2015", ok: true},
+		{input: "二〇一五", want: "2015", ok: true},
+		{input: "〇〇七", want: "7", ok: true},
+		{input: "3万5千", want: "35000", ok: true},
+		{input: "３０００", want: "3000", ok: true},
+		{input: "三千二百二十三", want: "3223", ok: true},
+		{input: "十二", want: "12", ok: true},
+		{input: "千", want: "1000", ok: true},
+		{input: "１．２万３４５．６７", want: "12345.67", ok: true},
+		{input: "4,647.100", want: "4647.1", ok: true},
+		{input: "1.25万", want: "12500", ok: true},
+		{input: "九千九百九十九京", want: "99990000000000000000", ok: true},
+		{input: "二億三千万", want: "230000000", ok: true},
+		{input: "1.2.3", ok: false},
+		{input: "万万", ok: false},
+		{input: "百千", ok: false},
+		{input: ",5", ok: false},
+		{input: "", ok: false},
+	}
+	for _, v := range testdata {
+		t.Run(v.input, func(t *testing.T) {
+			got, ok := NormalizeNumber(v.input)
+			if ok != v.ok || got != v.want {
+				t.Errorf("got %q, %v, want %q, %v", got, ok, v.want, v.ok)
+			}
+		})
+	}
+}
+
+func TestNumberFilter(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatal(err)
+	}
+	testdata := []struct {
+		input string
+		want  []string
+	}{
+		{input: "三千円", want: []string{"3000", "円"}},
+		{input: "3000円", want: []string{"3000", "円"}},
+		{input: "３０００円", want: []string{"3000", "円"}},
+		{input: "3万5千人", want: []string{"35000", "人"}},
+		{input: "二千十五年", want: []string{"2015", "年"}},
+		{input: "1,980円と2,480円", want: []string{"1980", "円", "と", "2480", "円"}},
+		{input: "version 1.2.3", want: []string{"version", " ", "1", ".", "2", ".", "3"}},
+		{input: "万全", want: []string{"万全"}},
+		{input: "万一の場合", want: []string{"万一", "の", "場合"}},
+	}
+	for _, v := range testdata {
+		t.Run(v.input, func(t *testing.T) {
+			tokens := tz.Tokenize(v.input)
+			NewNumberFilter().Apply(&tokens)
+			var got []string
+			for _, tk := range tokens {
+				got = append(got, tk.Surface)
+			}
+			if !reflect.DeepEqual(got, v.want) {
+				t.Errorf("got %q, want %q", got, v.want)
+			}
+		})
+	}
+	t.Run("offsets", func(t *testing.T) {
+		tokens := tz.Tokenize("約3万5千人")
+		NewNumberFilter().Apply(&tokens)
+		if got, want := tokens[1].Start, 1; got != want {
+			t.Errorf("start: got %d, want %d", got, want)
+		}
+		if got, want := tokens[1].End, 5; got != want {
+			t.Errorf("end: got %d, want %d", got, want)
+		}
+	})
+	t.Run("chain config", func(t *testing.T) {
+		c, err := filter.LoadChain(strings.NewReader("filters: [{type: ja_number}]"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, want := c.Yield(tz.Tokenize("三千円")), []string{"3000", "円"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+	t.Run("base form chain", func(t *testing.T) {
+		c, err := filter.LoadChain(strings.NewReader("filters: [{type: ja_number}, {type: base_form}]"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		for input, want := range map[string][]string{
+			"三千円":   {"3000", "円"},
+			"3000円": {"3000", "円"},
+			"二千十五年": {"2015", "年"},
+		} {
+			if got := c.Yield(tz.Tokenize(input)); !reflect.DeepEqual(got, want) {
+				t.Errorf("input %q: got %q, want %q", input, got, want)
+			}
+		}
+	})
+	t.Run("merged token", func(t *testing.T) {
+		tokens := tz.Tokenize("3000円")
+		NewNumberFilter().Apply(&tokens)
+		if got, want := tokens[0].Class, tokenizer.SYNTHETIC; got != want {
+			t.Errorf("class: got %v, want %v", got, want)
+		}
+		if base, ok := tokens[0].BaseForm(); !ok || base != "3000" {
+			t.Errorf("base form: got %q, %v, want %q", base, ok, "3000")
+		}
+		if r, ok := tokens[0].Reading(); ok {
+			t.Errorf("unexpected reading, %q", r)
+		}
+		if got, want := tokens[0].POS(), []string{"名詞", "数", "*", "*"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("pos: got %v, want %v", got, want)
+		}
+	})
+}
@@ -1,6 +1,9 @@
 package filter
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/ikawaha/kagome/v2/tokenizer"
 )
 
@@ -11,7 +14,8 @@ type (
 
 // POSFilter represents a part-of-speech filter.
 type POSFilter struct {
-	filter *FeaturesFilter
+	filter  *FeaturesFilter
+	exclude *FeaturesFilter
 }
 
 // NewPOSFilter returns a part-of-speech filter.
@@ -21,8 +25,62 @@ func NewPOSFilter(p ...POS) *POSFilter {
 	}
 }
 
+// NewPOSFilterFromPatterns returns a part-of-speech filter described by
+// the patterns. A pattern is a comma separated list of the parts-of-speech
+// whose features are joined with a hyphen. The feature "*" matches any
+// feature, and a part-of-speech with the prefix "!" excludes the matches,
+// e.g. "名詞-固有名詞-*" or "動詞-*-*,!動詞-非自立". If there are only
+// exclusions, the filter matches all the other parts-of-speech.
+func NewPOSFilterFromPatterns(patterns ...string) (*POSFilter, error) {
+	var include, exclude []POS
+	for _, v := range patterns {
+		for _, term := range strings.Split(v, ",") {
+			term = strings.TrimSpace(term)
+			neg := strings.HasPrefix(term, "!")
+			p, err := ParsePOSPattern(strings.TrimPrefix(term, "!"))
+			if err != nil {
+				return nil, err
+			}
+			if neg {
+				exclude = append(exclude, p)
+				continue
+			}
+			include = append(include, p)
+		}
+	}
+	if len(include) == 0 && len(exclude) > 0 {
+		include = append(include, POS{Any})
+	}
+	ret := NewPOSFilter(include...)
+	if len(exclude) > 0 {
+		ret.exclude = NewFeaturesFilter(exclude...)
+	}
+	return ret, nil
+}
+
+// ParsePOSPattern parses a part-of-speech whose features are joined with a
+// hyphen, e.g. "名詞-固有名詞-*". The feature "*" is parsed as Any.
+func ParsePOSPattern(s string) (POS, error) {
+	if s == "" {
+		return nil, fmt.Errorf("empty part-of-speech pattern")
+	}
+	ret := strings.Split(s, "-")
+	for i, v := range ret {
+		switch v {
+		case "":
+			return nil, fmt.Errorf("empty feature in part-of-speech pattern, %q", s)
+		case "*":
+			ret[i] = Any
+		}
+	}
+	return ret, nil
+}
+
 // Match returns true if a filter matches given POS.
 func (f POSFilter) Match(p POS) bool {
+	if f.exclude != nil && f.exclude.Match(p) {
+		return false
+	}
 	return f.filter.Match(p)
 }
 
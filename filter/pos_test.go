@@ -223,3 +223,67 @@ func ExamplePOSFilter() {
 	// 小川 [名詞 固有名詞 人名 姓]
 	// 未明 [名詞 固有名詞 人名 名]
 }
+
+func TestNewPOSFilterFromPatterns(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		panic(err)
+	}
+	tnz, err := tokenizer.New(d)
+	if err != nil {
+		panic(err)
+	}
+	tokens := tnz.Tokenize(input)
+
+	testdata := []struct {
+		title    string
+		patterns []string
+		want     []string
+	}{
+		{
+			title:    "wildcard",
+			patterns: []string{"名詞-*-一般"},
+			want:     []string{"方", "の"},
+		},
+		{
+			title:    "comma separated",
+			patterns: []string{"名詞-非自立, 助動詞"},
+			want:     []string{"方", "の", "で", "ませ", "ん"},
+		},
+		{
+			title:    "negation",
+			patterns: []string{"動詞-*", "!動詞-非自立"},
+			want:     []string{"棲ん", "あり"},
+		},
+		{
+			title:    "negation only",
+			patterns: []string{"!名詞,!助詞,!助動詞,!記号"},
+			want:     []string{"赤い", "棲ん", "いる", "あり"},
+		},
+	}
+	for _, v := range testdata {
+		t.Run(v.title, func(t *testing.T) {
+			fl, err := filter.NewPOSFilterFromPatterns(v.patterns...)
+			if err != nil {
+				t.Fatalf("unexpected error, %v", err)
+			}
+			var got []string
+			for _, token := range tokens {
+				if fl.Match(token.POS()) {
+					got = append(got, token.Surface)
+				}
+			}
+			if !reflect.DeepEqual(v.want, got) {
+				t.Errorf("want %+v, got %+v", v.want, got)
+			}
+		})
+	}
+
+	t.Run("invalid patterns", func(t *testing.T) {
+		for _, v := range []string{"", "!", "名詞--一般", "名詞,", "名詞-"} {
+			if _, err := filter.NewPOSFilterFromPatterns(v); err == nil {
+				t.Errorf("expected error, %q", v)
+			}
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package filter
+
+import (
+	"unicode/utf8"
+)
+
+// SentenceSpan represents a sentence in the input. The sentence is
+// input[Start:End], which may contain the white spaces the splitter skips.
+type SentenceSpan struct {
+	Start     int // byte offset
+	End       int // byte offset
+	RuneStart int // rune offset
+	RuneEnd   int // rune offset
+}
+
+// SentenceSpans splits the input into sentences with the default splitter
+// and returns their spans in the input.
+func SentenceSpans(input string) []SentenceSpan {
+	return defaultSplitter.Spans(input)
+}
+
+// Spans splits the input into sentences and returns their spans in the
+// input. The sentences are the same as those of ScanSentences, but the
+// input is not modified.
+func (s SentenceSplitter) Spans(input string) []SentenceSpan {
+	var (
+		ret   []SentenceSpan
+		buf   = []byte(input) // ScanSentences compacts the white spaces in place.
+		runes = runeCounter{input: input}
+	)
+	for off := 0; off < len(buf); {
+		advance, token, _ := s.ScanSentences(buf[off:], true)
+		if advance <= 0 {
+			break
+		}
+		if len(token) > 0 {
+			start, end := matchToken(input[off:off+advance], token)
+			sp := SentenceSpan{
+				Start: off + start,
+				End:   off + end,
+			}
+			sp.RuneStart = runes.count(sp.Start)
+			sp.RuneEnd = runes.count(sp.End)
+			ret = append(ret, sp)
+		}
+		off += advance
+	}
+	return ret
+}
+
+// matchToken returns the byte range of src which contains the runes of
+// the token in order. The runes of src which are not in the token are the
+// white spaces the splitter skipped.
+func matchToken(src string, token []byte) (start, end int) {
+	start = -1
+	for p := 0; p < len(src) && len(token) > 0; {
+		r, size := utf8.DecodeRuneInString(src[p:])
+		t, tsize := utf8.DecodeRune(token)
+		if r == t {
+			if start < 0 {
+				start = p
+			}
+			token = token[tsize:]
+			end = p + size
+		}
+		p += size
+	}
+	if start < 0 {
+		start = end
+	}
+	return start, end
+}
+
+// runeCounter counts the runes of the input incrementally. The offsets
+// must be given in ascending order.
+type runeCounter struct {
+	input string
+	pos   int // byte offset
+	runes int // rune offset of pos
+}
+
+func (c *runeCounter) count(pos int) int {
+	c.runes += utf8.RuneCountInString(c.input[c.pos:pos])
+	c.pos = pos
+	return c.runes
+}
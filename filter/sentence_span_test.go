@@ -0,0 +1,88 @@
+package filter_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/ikawaha/kagome/v2/filter"
+)
+
+func TestSentenceSpans(t *testing.T) {
+	testdata := []struct {
+		title  string
+		input  string
+		expect []filter.SentenceSpan
+	}{
+		{
+			title:  "empty",
+			input:  "",
+			expect: nil,
+		},
+		{
+			title: "sentences",
+			input: "こんにちは。さようなら",
+			expect: []filter.SentenceSpan{
+				{Start: 0, End: 18, RuneStart: 0, RuneEnd: 6},
+				{Start: 18, End: 33, RuneStart: 6, RuneEnd: 11},
+			},
+		},
+		{
+			title: "white spaces",
+			input: "　 こ ん\nにちは。 ．」\n\nさよ\tうなら\n",
+			expect: []filter.SentenceSpan{
+				{Start: 4, End: 31, RuneStart: 2, RuneEnd: 13},
+				{Start: 33, End: 49, RuneStart: 15, RuneEnd: 21},
+			},
+		},
+	}
+	for _, v := range testdata {
+		t.Run(v.title, func(t *testing.T) {
+			got := filter.SentenceSpans(v.input)
+			if !reflect.DeepEqual(got, v.expect) {
+				t.Errorf("got %+v, expected %+v", got, v.expect)
+			}
+			for _, sp := range got {
+				if n := utf8.RuneCountInString(v.input[:sp.Start]); n != sp.RuneStart {
+					t.Errorf("rune start %d, expected %d", sp.RuneStart, n)
+				}
+				if n := utf8.RuneCountInString(v.input[:sp.End]); n != sp.RuneEnd {
+					t.Errorf("rune end %d, expected %d", sp.RuneEnd, n)
+				}
+			}
+		})
+	}
+}
+
+func TestSentenceSplitter_Spans(t *testing.T) {
+	input := "　人魚は、南の方の海にばかり棲んでいるのではあ\n  りません。「北の海にも。」と言った。"
+	s := filter.SentenceSplitter{
+		Delim:               []rune{'。'},
+		Follower:            []rune{'」'},
+		SkipWhiteSpace:      true,
+		DoubleLineFeedSplit: true,
+		MaxRuneLen:          128,
+		Brackets:            [][2]rune{{'「', '」'}},
+	}
+	var got []string
+	for _, v := range s.Spans(input) {
+		got = append(got, input[v.Start:v.End])
+	}
+	expect := []string{
+		"人魚は、南の方の海にばかり棲んでいるのではあ\n  りません。",
+		"「北の海にも。」と言った。",
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("got %q, expected %q", got, expect)
+	}
+}
+
+func BenchmarkSentenceSpans(b *testing.B) {
+	input := strings.Repeat("吾輩は猫である。名前はまだ無い。詳細は https://example.com/a?b=1 を見よ！", 1024)
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		filter.SentenceSpans(input)
+	}
+}
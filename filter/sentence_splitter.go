@@ -1,8 +1,11 @@
 package filter
 
 import (
+	"regexp"
 	"unicode"
 	"unicode/utf8"
+
+	"github.com/ikawaha/kagome/v2/tokenizer"
 )
 
 // SentenceSplitter is a tiny sentence splitter for japanese texts.
@@ -12,6 +15,19 @@ type SentenceSplitter struct {
 	SkipWhiteSpace      bool   // eliminate white space or not
 	DoubleLineFeedSplit bool   // splite at '\n\n' or not
 	MaxRuneLen          int    // max sentence length
+	// Brackets is the set of the pairs of opening and closing brackets.
+	// ex. {{'「','」'},{'『','』'}}
+	Brackets [][2]rune
+	// SplitInBrackets splits at delimiters inside brackets or not. If not,
+	// a closing bracket which follows a delimiter ends the sentence when it
+	// closes the outermost bracket and is not followed by a hiragana,
+	// e.g. 「はい。」「いいえ。」 is split after 「はい。」, but 「はい。」と言った。
+	// is not.
+	SplitInBrackets bool
+	// Protect is the set of the patterns of the spans which are not split,
+	// e.g. URLs and emails which contain delimiters. A delimiter at the end
+	// of the data requests more data to see the whole span.
+	Protect []*regexp.Regexp
 }
 
 // default sentence splitter
@@ -21,6 +37,8 @@ var defaultSplitter = &SentenceSplitter{
 	SkipWhiteSpace:      true,
 	DoubleLineFeedSplit: true,
 	MaxRuneLen:          128,
+	Brackets:            [][2]rune{{'「', '」'}, {'『', '』'}, {'（', '）'}, {'【', '】'}},
+	Protect:             []*regexp.Regexp{tokenizer.URLRegexp, tokenizer.EmailRegexp},
 }
 
 // ScanSentences implements SplitFunc interface of bufio.Scanner that returns each sentence of text.
@@ -47,6 +65,102 @@ func (s SentenceSplitter) isFollower(r rune) bool {
 	return false
 }
 
+// brackets tracks the nesting of brackets.
+type brackets struct {
+	pairs [][2]rune
+	stack []rune // closing brackets
+}
+
+func (b *brackets) depth() int {
+	return len(b.stack)
+}
+
+// has reports whether the rune is an opening or closing bracket.
+func (b *brackets) has(r rune) bool {
+	for _, v := range b.pairs {
+		if r == v[0] || r == v[1] {
+			return true
+		}
+	}
+	return false
+}
+
+// update updates the nesting with the rune, and returns true if the rune is
+// a closing bracket which closes the outermost bracket.
+func (b *brackets) update(r rune) bool {
+	for _, v := range b.pairs {
+		if r == v[0] {
+			b.stack = append(b.stack, v[1])
+			return false
+		}
+	}
+	// a closing bracket closes the innermost matching bracket and the
+	// brackets inside it which are left open.
+	for i := len(b.stack) - 1; i >= 0; i-- {
+		if b.stack[i] == r {
+			b.stack = b.stack[:i]
+			return i == 0
+		}
+	}
+	return false
+}
+
+// protectedLookahead is the byte size of the data beyond the rune in
+// question which is searched for the protected spans.
+const protectedLookahead = 64
+
+// protected finds the spans of the data which match the protected patterns.
+// The splitter compacts the data while scanning, so the bytes are copied
+// to the window before they are overwritten. The spans are searched in the
+// head of the window up to the lookahead beyond the rune in question, so
+// that a call of the splitter reads the data only around the sentence it
+// returns.
+type protected struct {
+	patterns []*regexp.Regexp
+	window   []byte  // copy of the head of the data
+	spans    [][]int // spans in window[:searched]
+	searched int
+}
+
+// grow extends the window beyond the offset. It must be called before the
+// data at the offset is overwritten.
+func (pr *protected) grow(data []byte, offset int) {
+	if len(pr.patterns) == 0 || len(pr.window) == len(data) || offset+protectedLookahead <= len(pr.window) {
+		return
+	}
+	n := 2 * len(pr.window) // doubles the window to search the spans in linear time.
+	if n < offset+protectedLookahead {
+		n = offset + protectedLookahead
+	}
+	if n > len(data) {
+		n = len(data)
+	}
+	pr.window = append(pr.window, data[len(pr.window):n]...)
+}
+
+// contains reports whether the byte at the offset is inside a span.
+func (pr *protected) contains(offset int) bool {
+	if len(pr.patterns) == 0 {
+		return false
+	}
+	if end := offset + protectedLookahead; pr.searched < end && pr.searched < len(pr.window) {
+		if end > len(pr.window) {
+			end = len(pr.window)
+		}
+		pr.spans = pr.spans[:0]
+		for _, re := range pr.patterns {
+			pr.spans = append(pr.spans, re.FindAllIndex(pr.window[:end], -1)...)
+		}
+		pr.searched = end
+	}
+	for _, v := range pr.spans {
+		if v[0] <= offset && offset < v[1] {
+			return true
+		}
+	}
+	return false
+}
+
 // ScanSentences is a split function for a Scanner that returns each sentence of text.
 // nolint: gocyclo
 func (s SentenceSplitter) ScanSentences(data []byte, atEOF bool) (advance int, token []byte, err error) {
@@ -56,16 +170,20 @@ func (s SentenceSplitter) ScanSentences(data []byte, atEOF bool) (advance int, t
 	var (
 		start, end, rcount int
 		head, nn           bool // nn indicates \n\n
+		delim              bool // the previous rune is a delimiter inside brackets
+		nest               = brackets{pairs: s.Brackets}
+		protect            = protected{patterns: s.Protect}
 	)
 	head = true
 	for p := 0; p < len(data); {
 		r, size := utf8.DecodeRune(data[p:])
+		protect.grow(data, p)
 		if s.SkipWhiteSpace && unicode.IsSpace(r) {
 			p += size
 			switch {
 			case head:
 				start, end = p, p
-			case s.isDelim(r):
+			case s.isDelim(r) && (nest.depth() == 0 || s.SplitInBrackets):
 				return p, data[start:end], nil
 			case s.DoubleLineFeedSplit && r == '\n':
 				if nn {
@@ -92,9 +210,31 @@ func (s SentenceSplitter) ScanSentences(data []byte, atEOF bool) (advance int, t
 			}
 			nn = true
 		}
-		if !s.isDelim(r) && rcount < s.MaxRuneLen {
+		if (s.isDelim(r) || nest.has(r) || rcount >= s.MaxRuneLen) && protect.contains(p-size) {
+			continue // a protected span never splits.
+		}
+		closed := nest.update(r) && delim
+		delim = false
+		if closed {
+			if p >= len(data) && !atEOF {
+				break // request more data to see the next rune
+			}
+			if next, _ := utf8.DecodeRune(data[p:]); unicode.In(next, unicode.Hiragana) {
+				closed = false
+			}
+		}
+		if s.isDelim(r) && nest.depth() > 0 && !s.SplitInBrackets {
+			delim = true
+			if rcount < s.MaxRuneLen {
+				continue
+			}
+		}
+		if !s.isDelim(r) && !closed && rcount < s.MaxRuneLen {
 			continue
 		}
+		if len(s.Protect) > 0 && s.isDelim(r) && p >= len(data) && !atEOF {
+			break // request more data to see the span which may follow
+		}
 		// split
 		nn = false
 		for p < len(data) {
@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"reflect"
+	"regexp"
 	"strings"
 	"testing"
 
@@ -225,6 +226,92 @@ func Test_DisableSkipWhiteSpaceAndDoubleLineFeedSplit(t *testing.T) {
 	}
 }
 
+func Test_Brackets(t *testing.T) {
+	testdata := []struct {
+		input  string
+		expect []string
+	}{
+		{
+			input:  "「こんにちは。元気？」と彼は言った。次の文。",
+			expect: []string{"「こんにちは。元気？」と彼は言った。", "次の文。"},
+		},
+		{
+			input:  "「はい。」「いいえ。」\n彼は黙った。",
+			expect: []string{"「はい。」", "「いいえ。」", "彼は黙った。"},
+		},
+		{
+			input:  "『「入れ子。」の中。』と書いた。",
+			expect: []string{"『「入れ子。」の中。』と書いた。"},
+		},
+		{
+			input:  "注意（詳細は後述。）を読む。【速報。】",
+			expect: []string{"注意（詳細は後述。）を読む。", "【速報。】"},
+		},
+		{
+			input:  "閉じない「括弧。の中。",
+			expect: []string{"閉じない「括弧。の中。"},
+		},
+		{
+			input:  "余分な」括弧。です。",
+			expect: []string{"余分な」括弧。", "です。"},
+		},
+		{
+			input:  "「段落。\n\n次の段落。」",
+			expect: []string{"「段落。", "次の段落。」"},
+		},
+	}
+	for _, d := range testdata {
+		scanner := bufio.NewScanner(strings.NewReader(d.input))
+		scanner.Split(filter.ScanSentences)
+		r := make([]string, 0, len(d.expect))
+		for scanner.Scan() {
+			r = append(r, scanner.Text())
+		}
+		if !reflect.DeepEqual(r, d.expect) {
+			t.Errorf("input %v, got %#v, expected %#v", d.input, r, d.expect)
+		}
+	}
+
+	t.Run("split in brackets", func(t *testing.T) {
+		s := filter.SentenceSplitter{
+			Delim:               []rune{'。', '？'},
+			Follower:            []rune{'」'},
+			SkipWhiteSpace:      true,
+			DoubleLineFeedSplit: true,
+			MaxRuneLen:          128,
+			Brackets:            [][2]rune{{'「', '」'}},
+			SplitInBrackets:     true,
+		}
+		scanner := bufio.NewScanner(strings.NewReader("「こんにちは。元気？」と彼は言った。"))
+		scanner.Split(s.ScanSentences)
+		var r []string
+		for scanner.Scan() {
+			r = append(r, scanner.Text())
+		}
+		if want := []string{"「こんにちは。", "元気？」", "と彼は言った。"}; !reflect.DeepEqual(r, want) {
+			t.Errorf("got %#v, expected %#v", r, want)
+		}
+	})
+
+	t.Run("max rune length in brackets", func(t *testing.T) {
+		s := filter.SentenceSplitter{
+			Delim:      []rune{'。'},
+			Follower:   []rune{'」'},
+			MaxRuneLen: 4,
+			Brackets:   [][2]rune{{'「', '」'}},
+		}
+		scanner := bufio.NewScanner(strings.NewReader("「あいうえお。」"))
+		scanner.Split(s.ScanSentences)
+		var r []string
+		for scanner.Scan() {
+			r = append(r, scanner.Text())
+		}
+		if want := []string{"「あいう", "えお。」"}; !reflect.DeepEqual(r, want) {
+			t.Errorf("got %#v, expected %#v", r, want)
+		}
+	})
+}
+
 func Test_ScanSentences(t *testing.T) {
 	testdata := []struct {
 		atEnd   bool
@@ -274,3 +361,82 @@ func ExampleScanSentences() {
 	// あるとき、岩の上に、女の人魚があがって、あたりの景色をながめながら休んでいました。
 	// 小川未明作赤い蝋燭と人魚より
 }
+
+func Test_Protect(t *testing.T) {
+	testdata := []struct {
+		input  string
+		expect []string
+	}{
+		{
+			input:  "検索はhttps://example.com/search?q=1をどうぞ。次の文。",
+			expect: []string{"検索はhttps://example.com/search?q=1をどうぞ。", "次の文。"},
+		},
+		{
+			input:  "見て！https://example.com?x=1 です？はい。",
+			expect: []string{"見て！", "https://example.com?x=1です？", "はい。"},
+		},
+	}
+	for _, d := range testdata {
+		scanner := bufio.NewScanner(strings.NewReader(d.input))
+		scanner.Split(filter.ScanSentences)
+		r := make([]string, 0, len(d.expect))
+		for scanner.Scan() {
+			r = append(r, scanner.Text())
+		}
+		if !reflect.DeepEqual(r, d.expect) {
+			t.Errorf("input %v, got %#v, expected %#v", d.input, r, d.expect)
+		}
+	}
+
+	t.Run("long span", func(t *testing.T) {
+		url := "https://example.com/" + strings.Repeat("a", 300) + "?q=1&r=" + strings.Repeat("b", 300)
+		scanner := bufio.NewScanner(strings.NewReader(url + "！次の文。"))
+		scanner.Split(filter.ScanSentences)
+		var r []string
+		for scanner.Scan() {
+			r = append(r, scanner.Text())
+		}
+		if want := []string{url + "！", "次の文。"}; !reflect.DeepEqual(r, want) {
+			t.Errorf("got %#v, expected %#v", r, want)
+		}
+	})
+
+	t.Run("user pattern", func(t *testing.T) {
+		s := filter.SentenceSplitter{
+			Delim:          []rune{'。', '.'},
+			SkipWhiteSpace: true,
+			MaxRuneLen:     128,
+			Protect:        []*regexp.Regexp{regexp.MustCompile(`v[0-9]+(\.[0-9]+)*`)},
+		}
+		scanner := bufio.NewScanner(strings.NewReader("v1.2.3 を出した. 次は v2.0 だ。"))
+		scanner.Split(s.ScanSentences)
+		var r []string
+		for scanner.Scan() {
+			r = append(r, scanner.Text())
+		}
+		if want := []string{"v1.2.3を出した.", "次はv2.0だ。"}; !reflect.DeepEqual(r, want) {
+			t.Errorf("got %#v, expected %#v", r, want)
+		}
+	})
+
+	t.Run("more data", func(t *testing.T) {
+		// the delimiter at the end of the data may be a part of a URL.
+		data := []byte("https://example.com?")
+		advance, token, err := filter.ScanSentences(data, false)
+		if advance != 0 || token != nil || err != nil {
+			t.Errorf("got %v, %q, %v, expected a request for more data", advance, token, err)
+		}
+	})
+}
+
+func BenchmarkScanSentences(b *testing.B) {
+	input := strings.Repeat("吾輩は猫である。名前はまだ無い。詳細は https://example.com/a?b=1 を見よ！", 4096)
+	b.SetBytes(int64(len(input)))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		scanner := bufio.NewScanner(strings.NewReader(input))
+		scanner.Split(filter.ScanSentences)
+		for scanner.Scan() {
+		}
+	}
+}
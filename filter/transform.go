@@ -0,0 +1,78 @@
+package filter
+
+import (
+	"strings"
+
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// BaseFormFilter represents a filter which replaces the surface of a token
+// with its base form.
+type BaseFormFilter struct {
+	pos *POSFilter
+}
+
+// NewBaseFormFilter returns a base form filter. If no part-of-speech is
+// given, the filter applies to all tokens, otherwise only to the tokens
+// whose part-of-speech matches.
+func NewBaseFormFilter(p ...POS) *BaseFormFilter {
+	ret := &BaseFormFilter{}
+	if len(p) > 0 {
+		ret.pos = NewPOSFilter(p...)
+	}
+	return ret
+}
+
+// Apply replaces the surfaces of the tokens with their base forms.
+func (f BaseFormFilter) Apply(tokens *[]tokenizer.Token) {
+	replaceSurface(tokens, func(t tokenizer.Token) (string, bool) {
+		if f.pos != nil && !f.pos.Match(t.POS()) {
+			return "", false
+		}
+		return t.BaseForm()
+	})
+}
+
+// ReadingFilter represents a filter which replaces the surface of a token
+// with its reading.
+type ReadingFilter struct{}
+
+// NewReadingFilter returns a reading filter.
+func NewReadingFilter() *ReadingFilter {
+	return &ReadingFilter{}
+}
+
+// Apply replaces the surfaces of the tokens with their readings.
+func (f ReadingFilter) Apply(tokens *[]tokenizer.Token) {
+	replaceSurface(tokens, func(t tokenizer.Token) (string, bool) {
+		return t.Reading()
+	})
+}
+
+// LowercaseFilter represents a filter which lowercases the surface of a token.
+type LowercaseFilter struct{}
+
+// NewLowercaseFilter returns a lowercase filter.
+func NewLowercaseFilter() *LowercaseFilter {
+	return &LowercaseFilter{}
+}
+
+// Apply lowercases the surfaces of the tokens.
+func (f LowercaseFilter) Apply(tokens *[]tokenizer.Token) {
+	replaceSurface(tokens, func(t tokenizer.Token) (string, bool) {
+		return strings.ToLower(t.Surface), true
+	})
+}
+
+// replaceSurface replaces the surface of a token if the function returns
+// a value other than the empty string or "*".
+func replaceSurface(tokens *[]tokenizer.Token, fn func(t tokenizer.Token) (string, bool)) {
+	if tokens == nil {
+		return
+	}
+	for i, v := range *tokens {
+		if s, ok := fn(v); ok && s != "" && s != "*" {
+			(*tokens)[i].Surface = s
+		}
+	}
+}
@@ -6,4 +6,6 @@ require (
 	github.com/ikawaha/kagome-dict v1.1.0
 	github.com/ikawaha/kagome-dict/ipa v1.2.0
 	github.com/ikawaha/kagome-dict/uni v1.2.0
+	golang.org/x/text v0.16.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -9,10 +9,20 @@ import (
 	"runtime/debug"
 	"strings"
 
+	"github.com/ikawaha/kagome/v2/cmd/builddict"
+	"github.com/ikawaha/kagome/v2/cmd/dictinfo"
+	"github.com/ikawaha/kagome/v2/cmd/diff"
+	"github.com/ikawaha/kagome/v2/cmd/eval"
+	"github.com/ikawaha/kagome/v2/cmd/grep"
+	"github.com/ikawaha/kagome/v2/cmd/keywords"
 	"github.com/ikawaha/kagome/v2/cmd/lattice"
+	"github.com/ikawaha/kagome/v2/cmd/lookup"
 	"github.com/ikawaha/kagome/v2/cmd/sentence"
 	"github.com/ikawaha/kagome/v2/cmd/server"
 	"github.com/ikawaha/kagome/v2/cmd/tokenize"
+	"github.com/ikawaha/kagome/v2/cmd/train"
+	"github.com/ikawaha/kagome/v2/cmd/udict"
+	"github.com/ikawaha/kagome/v2/cmd/unknown"
 )
 
 type subcommand struct {
@@ -60,6 +70,86 @@ var (
 			OptionCheck:   sentence.OptionCheck,
 			PrintDefaults: sentence.PrintDefaults,
 		},
+		{
+			Name:          builddict.CommandName,
+			Description:   builddict.Description,
+			Run:           builddict.Run,
+			Usage:         builddict.Usage,
+			OptionCheck:   builddict.OptionCheck,
+			PrintDefaults: builddict.PrintDefaults,
+		},
+		{
+			Name:          udict.CommandName,
+			Description:   udict.Description,
+			Run:           udict.Run,
+			Usage:         udict.Usage,
+			OptionCheck:   udict.OptionCheck,
+			PrintDefaults: udict.PrintDefaults,
+		},
+		{
+			Name:          lookup.CommandName,
+			Description:   lookup.Description,
+			Run:           lookup.Run,
+			Usage:         lookup.Usage,
+			OptionCheck:   lookup.OptionCheck,
+			PrintDefaults: lookup.PrintDefaults,
+		},
+		{
+			Name:          dictinfo.CommandName,
+			Description:   dictinfo.Description,
+			Run:           dictinfo.Run,
+			Usage:         dictinfo.Usage,
+			OptionCheck:   dictinfo.OptionCheck,
+			PrintDefaults: dictinfo.PrintDefaults,
+		},
+		{
+			Name:          train.CommandName,
+			Description:   train.Description,
+			Run:           train.Run,
+			Usage:         train.Usage,
+			OptionCheck:   train.OptionCheck,
+			PrintDefaults: train.PrintDefaults,
+		},
+		{
+			Name:          eval.CommandName,
+			Description:   eval.Description,
+			Run:           eval.Run,
+			Usage:         eval.Usage,
+			OptionCheck:   eval.OptionCheck,
+			PrintDefaults: eval.PrintDefaults,
+		},
+		{
+			Name:          diff.CommandName,
+			Description:   diff.Description,
+			Run:           diff.Run,
+			Usage:         diff.Usage,
+			OptionCheck:   diff.OptionCheck,
+			PrintDefaults: diff.PrintDefaults,
+		},
+		{
+			Name:          unknown.CommandName,
+			Description:   unknown.Description,
+			Run:           unknown.Run,
+			Usage:         unknown.Usage,
+			OptionCheck:   unknown.OptionCheck,
+			PrintDefaults: unknown.PrintDefaults,
+		},
+		{
+			Name:          keywords.CommandName,
+			Description:   keywords.Description,
+			Run:           keywords.Run,
+			Usage:         keywords.Usage,
+			OptionCheck:   keywords.OptionCheck,
+			PrintDefaults: keywords.PrintDefaults,
+		},
+		{
+			Name:          grep.CommandName,
+			Description:   grep.Description,
+			Run:           grep.Run,
+			Usage:         grep.Usage,
+			OptionCheck:   grep.OptionCheck,
+			PrintDefaults: grep.PrintDefaults,
+		},
 		{
 			Name:        "version",
 			Description: "show version",
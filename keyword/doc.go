@@ -0,0 +1,2 @@
+// Package keyword extracts keywords from token sequences by TF-IDF or TextRank.
+package keyword
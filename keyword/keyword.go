@@ -0,0 +1,91 @@
+package keyword
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/ikawaha/kagome/v2/filter/ja"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// Keyword represents a scored term.
+type Keyword struct {
+	Word  string  `json:"word"`
+	Score float64 `json:"score"`
+}
+
+const nounPOS = "名詞"
+
+// Extractor extracts candidate terms from tokens.
+type Extractor struct {
+	// JoinCompounds joins a run of nouns into a compound noun.
+	JoinCompounds bool
+	filter        *ja.Filter
+}
+
+// NewExtractor returns an extractor which selects the content words with the filter.
+func NewExtractor(f *ja.Filter) *Extractor {
+	return &Extractor{
+		JoinCompounds: true,
+		filter:        f,
+	}
+}
+
+// Terms returns the candidate terms of the tokens in order of appearance.
+// The tokens dropped by the filter are removed, verbs and adjectives are
+// replaced with their base forms according to the filter.
+func (e Extractor) Terms(tokens []tokenizer.Token) []string {
+	var (
+		ret      []string
+		compound strings.Builder
+	)
+	flush := func() {
+		if compound.Len() > 0 {
+			ret = append(ret, compound.String())
+			compound.Reset()
+		}
+	}
+	for _, v := range tokens {
+		if v.Class == tokenizer.DUMMY {
+			flush()
+			continue
+		}
+		words := e.filter.Yield([]tokenizer.Token{v})
+		if len(words) == 0 {
+			flush()
+			continue
+		}
+		if e.JoinCompounds && isNoun(v) {
+			compound.WriteString(v.Surface)
+			continue
+		}
+		flush()
+		ret = append(ret, words...)
+	}
+	flush()
+	return ret
+}
+
+func isNoun(t tokenizer.Token) bool {
+	pos := t.POS()
+	return len(pos) > 0 && pos[0] == nounPOS
+}
+
+// top sorts the scores in descending order and returns at most n keywords.
+// If n <= 0, it returns all the keywords.
+func top(scores map[string]float64, n int) []Keyword {
+	ret := make([]Keyword, 0, len(scores))
+	for k, v := range scores {
+		ret = append(ret, Keyword{Word: k, Score: v})
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Score != ret[j].Score {
+			return ret[i].Score > ret[j].Score
+		}
+		return ret[i].Word < ret[j].Word
+	})
+	if n > 0 && len(ret) > n {
+		ret = ret[:n]
+	}
+	return ret
+}
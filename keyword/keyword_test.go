@@ -0,0 +1,43 @@
+package keyword
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/filter/ja"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+const testDictPath = "../testdata/ipa.dict"
+
+func TestExtractor_Terms(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	f, err := ja.NewFilter()
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tokens := tz.Tokenize("関西国際空港から東京都庁まで電車で移動した。")
+	t.Run("join compounds", func(t *testing.T) {
+		e := NewExtractor(f)
+		want := []string{"関西国際空港", "東京都庁", "電車", "移動"}
+		if got := e.Terms(tokens); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+	t.Run("without joining compounds", func(t *testing.T) {
+		e := NewExtractor(f)
+		e.JoinCompounds = false
+		want := []string{"関西国際空港", "東京", "都庁", "電車", "移動"}
+		if got := e.Terms(tokens); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+}
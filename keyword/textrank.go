@@ -0,0 +1,78 @@
+package keyword
+
+import (
+	"math"
+)
+
+// TextRank parameters.
+const (
+	DefaultWindow = 2
+	dampingFactor = 0.85
+	maxIteration  = 100
+	tolerance     = 1e-6
+)
+
+// TextRank scores the terms of a document by PageRank over the co-occurrence
+// graph which links the terms appearing within the window and returns the top
+// n keywords. If window < 2, DefaultWindow is used.
+func TextRank(terms []string, window, n int) []Keyword {
+	if len(terms) == 0 {
+		return nil
+	}
+	if window < 2 {
+		window = DefaultWindow
+	}
+	index := map[string]int{}
+	var words []string
+	for _, v := range terms {
+		if _, ok := index[v]; !ok {
+			index[v] = len(words)
+			words = append(words, v)
+		}
+	}
+	edges := make([]map[int]float64, len(words))
+	for i := range edges {
+		edges[i] = map[int]float64{}
+	}
+	for i := range terms {
+		for j := i + 1; j < i+window && j < len(terms); j++ {
+			a, b := index[terms[i]], index[terms[j]]
+			if a == b {
+				continue
+			}
+			edges[a][b]++
+			edges[b][a]++
+		}
+	}
+	out := make([]float64, len(words))
+	for i, v := range edges {
+		for _, w := range v {
+			out[i] += w
+		}
+	}
+	score := make([]float64, len(words))
+	for i := range score {
+		score[i] = 1
+	}
+	for it := 0; it < maxIteration; it++ {
+		next := make([]float64, len(words))
+		var diff float64
+		for i, v := range edges {
+			var sum float64
+			for j, w := range v {
+				sum += w / out[j] * score[j]
+			}
+			next[i] = 1 - dampingFactor + dampingFactor*sum
+			diff += math.Abs(next[i] - score[i])
+		}
+		score = next
+		if diff < tolerance {
+			break
+		}
+	}
+	scores := make(map[string]float64, len(words))
+	for i, v := range words {
+		scores[v] = score[i]
+	}
+	return top(scores, n)
+}
@@ -0,0 +1,28 @@
+package keyword
+
+import (
+	"testing"
+)
+
+func TestTextRank(t *testing.T) {
+	// 東京 co-occurs with all the other terms.
+	terms := []string{"東京", "空港", "東京", "電車", "東京", "駅", "大阪"}
+	got := TextRank(terms, 2, 0)
+	if len(got) != 5 {
+		t.Fatalf("got %v, want 5 keywords", got)
+	}
+	if got[0].Word != "東京" {
+		t.Errorf("got %v, want 東京 first", got)
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i-1].Score < got[i].Score {
+			t.Errorf("not sorted, %v", got)
+		}
+	}
+	if got := TextRank(terms, 2, 2); len(got) != 2 {
+		t.Errorf("got %v, want 2 keywords", got)
+	}
+	if got := TextRank(nil, 2, 2); got != nil {
+		t.Errorf("got %v, want nil", got)
+	}
+}
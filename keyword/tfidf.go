@@ -0,0 +1,116 @@
+package keyword
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const idfHeader = "#documents"
+
+// IDF represents a document frequency table built from a corpus.
+type IDF struct {
+	Documents int
+	DF        map[string]int
+}
+
+// NewIDF returns an empty document frequency table.
+func NewIDF() *IDF {
+	return &IDF{
+		DF: map[string]int{},
+	}
+}
+
+// Add counts the terms of a document.
+func (idf *IDF) Add(terms []string) {
+	idf.Documents++
+	seen := make(map[string]struct{}, len(terms))
+	for _, v := range terms {
+		if _, ok := seen[v]; ok {
+			continue
+		}
+		seen[v] = struct{}{}
+		idf.DF[v]++
+	}
+}
+
+// Weight returns the smoothed inverse document frequency of a term,
+// log((N+1)/(df+1))+1.
+func (idf IDF) Weight(term string) float64 {
+	return math.Log(float64(idf.Documents+1)/float64(idf.DF[term]+1)) + 1
+}
+
+// WriteTo writes the table in the TSV format. The first line holds the
+// number of documents, and the following lines hold the terms and their
+// document frequencies.
+func (idf IDF) WriteTo(w io.Writer) (int64, error) {
+	terms := make([]string, 0, len(idf.DF))
+	for k := range idf.DF {
+		terms = append(terms, k)
+	}
+	sort.Strings(terms)
+	var n int64
+	bw := bufio.NewWriter(w)
+	c, err := fmt.Fprintf(bw, "%s\t%d\n", idfHeader, idf.Documents)
+	n += int64(c)
+	if err != nil {
+		return n, err
+	}
+	for _, v := range terms {
+		c, err := fmt.Fprintf(bw, "%s\t%d\n", v, idf.DF[v])
+		n += int64(c)
+		if err != nil {
+			return n, err
+		}
+	}
+	return n, bw.Flush()
+}
+
+// ReadIDF reads a table written by WriteTo.
+func ReadIDF(r io.Reader) (*IDF, error) {
+	ret := NewIDF()
+	s := bufio.NewScanner(r)
+	for line := 1; s.Scan(); line++ {
+		if s.Text() == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(s.Text(), "\t")
+		if !ok {
+			return nil, fmt.Errorf("invalid format: line %d", line)
+		}
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return nil, fmt.Errorf("invalid format: line %d, %w", line, err)
+		}
+		if k == idfHeader {
+			ret.Documents = n
+			continue
+		}
+		ret.DF[k] = n
+	}
+	return ret, s.Err()
+}
+
+// TFIDF scores the terms of a document by the term frequency times the
+// inverse document frequency and returns the top n keywords. If idf is nil,
+// the terms are scored by the term frequency only.
+func TFIDF(terms []string, idf *IDF, n int) []Keyword {
+	if len(terms) == 0 {
+		return nil
+	}
+	tf := map[string]float64{}
+	for _, v := range terms {
+		tf[v]++
+	}
+	for k, v := range tf {
+		tf[k] = v / float64(len(terms))
+		if idf != nil {
+			tf[k] *= idf.Weight(k)
+		}
+	}
+	return top(tf, n)
+}
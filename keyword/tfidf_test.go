@@ -0,0 +1,74 @@
+package keyword
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestIDF(t *testing.T) {
+	idf := NewIDF()
+	idf.Add([]string{"東京", "空港", "東京"})
+	idf.Add([]string{"東京", "電車"})
+	if got, want := idf.DF, map[string]int{"東京": 2, "空港": 1, "電車": 1}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if got, want := idf.Weight("東京"), 1.0; math.Abs(got-want) > 1e-9 {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if idf.Weight("空港") <= idf.Weight("東京") {
+		t.Errorf("rare term should be weighted, %v <= %v", idf.Weight("空港"), idf.Weight("東京"))
+	}
+
+	var b bytes.Buffer
+	if _, err := idf.WriteTo(&b); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if got, want := b.String(), "#documents\t2\n東京\t2\n空港\t1\n電車\t1\n"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	got, err := ReadIDF(&b)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if !reflect.DeepEqual(got, idf) {
+		t.Errorf("got %+v, want %+v", got, idf)
+	}
+}
+
+func TestReadIDF_InvalidFormat(t *testing.T) {
+	for _, v := range []string{"東京", "東京\tx"} {
+		if _, err := ReadIDF(bytes.NewBufferString(v)); err == nil {
+			t.Errorf("expected error, %q", v)
+		}
+	}
+}
+
+func TestTFIDF(t *testing.T) {
+	terms := []string{"東京", "空港", "東京", "電車"}
+	t.Run("term frequency", func(t *testing.T) {
+		want := []Keyword{{Word: "東京", Score: 0.5}, {Word: "空港", Score: 0.25}}
+		if got := TFIDF(terms, nil, 2); !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+	})
+	t.Run("with idf", func(t *testing.T) {
+		idf := NewIDF()
+		idf.Add([]string{"東京"})
+		idf.Add([]string{"東京", "電車"})
+		idf.Add([]string{"東京", "電車"})
+		got := TFIDF(terms, idf, 0)
+		if len(got) != 3 {
+			t.Fatalf("got %v, want 3 keywords", got)
+		}
+		if got[0].Word != "空港" || got[1].Word != "東京" || got[2].Word != "電車" {
+			t.Errorf("unexpected order, %v", got)
+		}
+	})
+	t.Run("empty", func(t *testing.T) {
+		if got := TFIDF(nil, nil, 10); got != nil {
+			t.Errorf("got %v, want nil", got)
+		}
+	})
+}
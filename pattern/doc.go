@@ -0,0 +1,44 @@
+/*
+Package pattern implements regular expressions over token sequences.
+
+A pattern is a sequence of token predicates enclosed in brackets, e.g.
+
+	[pos:名詞]+ [surface:の] [pos:名詞]
+
+A predicate tests a field of a token:
+
+	surface   the surface
+	base      the base form (alias: base_form)
+	reading   the reading
+	pron      the pronunciation (alias: pronunciation)
+	pos       the part-of-speech
+
+The value is a bare word, a double-quoted string or a regular expression
+enclosed in slashes. A bare word or a string of the surface, base form,
+reading and pronunciation matches the whole field, and a regular expression
+matches a part of it, e.g. [reading:/^ア/]. A value of the part-of-speech is
+a pattern of filter.ParsePOSPattern which matches the leading features, e.g.
+[pos:名詞-固有名詞] or [pos:名詞-*-一般], and a regular expression matches
+the features joined with a hyphen. Predicates can be combined with !, &, |
+and parentheses, e.g. [pos:助動詞 & !base:ない]. Empty brackets [] match
+any token.
+
+The predicates are combined with the following operators:
+
+	xy         x followed by y
+	x|y        x or y
+	x*         zero or more x
+	x+         one or more x
+	x?         zero or one x
+	x{n}       exactly n x
+	x{n,}      n or more x
+	x{n,m}     n to m x
+	(x)        numbered capturing group
+	(?<name>x) named capturing group
+	(?:x)      non-capturing group
+
+The quantifiers prefer more repetitions, and the quantifiers followed by ?
+prefer fewer. The patterns are matched by backtracking, so a pattern with
+nested quantifiers may take time on a long sequence.
+*/
+package pattern
@@ -0,0 +1,347 @@
+package pattern
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// SyntaxError represents an error of a pattern.
+type SyntaxError struct {
+	Expr string
+	Pos  int // byte position
+	Msg  string
+}
+
+// Error implements error interface.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("pattern: %s at position %d of %q", e.Msg, e.Pos, e.Expr)
+}
+
+type parser struct {
+	expr  string
+	pos   int
+	names []string // the names of the groups, "" for unnamed groups.
+}
+
+func (p *parser) errorf(format string, a ...any) error {
+	return &SyntaxError{Expr: p.expr, Pos: p.pos, Msg: fmt.Sprintf(format, a...)}
+}
+
+func (p *parser) skipSpaces() {
+	for p.pos < len(p.expr) {
+		r, size := utf8.DecodeRuneInString(p.expr[p.pos:])
+		if !unicode.IsSpace(r) {
+			return
+		}
+		p.pos += size
+	}
+}
+
+// peek returns the next byte after spaces, or 0 at the end.
+func (p *parser) peek() byte {
+	p.skipSpaces()
+	if p.pos >= len(p.expr) {
+		return 0
+	}
+	return p.expr[p.pos]
+}
+
+func (p *parser) consume(s string) bool {
+	p.skipSpaces()
+	if strings.HasPrefix(p.expr[p.pos:], s) {
+		p.pos += len(s)
+		return true
+	}
+	return false
+}
+
+func (p *parser) parse() (node, error) {
+	n, err := p.parseAlt()
+	if err != nil {
+		return nil, err
+	}
+	if p.peek() != 0 {
+		return nil, p.errorf("unexpected %q", p.expr[p.pos])
+	}
+	return n, nil
+}
+
+func (p *parser) parseAlt() (node, error) {
+	var alt altNode
+	for {
+		n, err := p.parseConcat()
+		if err != nil {
+			return nil, err
+		}
+		alt = append(alt, n)
+		if !p.consume("|") {
+			break
+		}
+	}
+	if len(alt) == 1 {
+		return alt[0], nil
+	}
+	return alt, nil
+}
+
+func (p *parser) parseConcat() (node, error) {
+	var seq concatNode
+	for {
+		switch p.peek() {
+		case '[', '(':
+		default:
+			if len(seq) == 0 {
+				return nil, p.errorf("missing token pattern")
+			}
+			if len(seq) == 1 {
+				return seq[0], nil
+			}
+			return seq, nil
+		}
+		n, err := p.parseRepeat()
+		if err != nil {
+			return nil, err
+		}
+		seq = append(seq, n)
+	}
+}
+
+func (p *parser) parseRepeat() (node, error) {
+	n, err := p.parseAtom()
+	if err != nil {
+		return nil, err
+	}
+	lo, hi := 0, -1
+	switch p.peek() {
+	case '*':
+		p.pos++
+	case '+':
+		p.pos++
+		lo = 1
+	case '?':
+		p.pos++
+		hi = 1
+	case '{':
+		if lo, hi, err = p.parseBounds(); err != nil {
+			return nil, err
+		}
+	default:
+		return n, nil
+	}
+	lazy := p.consume("?")
+	switch p.peek() {
+	case '*', '+', '?', '{':
+		return nil, p.errorf("nested quantifier")
+	}
+	return &repeatNode{sub: n, min: lo, max: hi, lazy: lazy}, nil
+}
+
+func (p *parser) parseBounds() (lo, hi int, err error) {
+	start := p.pos
+	end := strings.IndexByte(p.expr[p.pos:], '}')
+	if end < 0 {
+		return 0, 0, p.errorf("missing }")
+	}
+	body := p.expr[p.pos+1 : p.pos+end]
+	p.pos += end + 1
+	l, h, ok := strings.Cut(body, ",")
+	if lo, err = strconv.Atoi(strings.TrimSpace(l)); err != nil || lo < 0 {
+		p.pos = start
+		return 0, 0, p.errorf("invalid repeat count {%s}", body)
+	}
+	switch {
+	case !ok:
+		hi = lo
+	case strings.TrimSpace(h) == "":
+		hi = -1
+	default:
+		if hi, err = strconv.Atoi(strings.TrimSpace(h)); err != nil || hi < lo {
+			p.pos = start
+			return 0, 0, p.errorf("invalid repeat count {%s}", body)
+		}
+	}
+	return lo, hi, nil
+}
+
+func (p *parser) parseAtom() (node, error) {
+	if p.consume("[") {
+		if p.consume("]") {
+			return tokenNode{pred: anyPredicate{}}, nil
+		}
+		pred, err := p.parsePredOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume("]") {
+			return nil, p.errorf("missing ]")
+		}
+		return tokenNode{pred: pred}, nil
+	}
+	if !p.consume("(") {
+		return nil, p.errorf("missing token pattern")
+	}
+	index := -1
+	switch {
+	case p.consume("?:"):
+	case p.consume("?<"):
+		end := strings.IndexByte(p.expr[p.pos:], '>')
+		if end <= 0 {
+			return nil, p.errorf("invalid group name")
+		}
+		index = len(p.names) + 1
+		p.names = append(p.names, p.expr[p.pos:p.pos+end])
+		p.pos += end + 1
+	default:
+		index = len(p.names) + 1
+		p.names = append(p.names, "")
+	}
+	n, err := p.parseAlt()
+	if err != nil {
+		return nil, err
+	}
+	if !p.consume(")") {
+		return nil, p.errorf("missing )")
+	}
+	if index < 0 {
+		return n, nil
+	}
+	return &groupNode{sub: n, index: index}, nil
+}
+
+func (p *parser) parsePredOr() (predicate, error) {
+	var or orPredicate
+	for {
+		pred, err := p.parsePredAnd()
+		if err != nil {
+			return nil, err
+		}
+		or = append(or, pred)
+		if !p.consume("|") {
+			break
+		}
+	}
+	if len(or) == 1 {
+		return or[0], nil
+	}
+	return or, nil
+}
+
+func (p *parser) parsePredAnd() (predicate, error) {
+	var and andPredicate
+	for {
+		pred, err := p.parsePredNot()
+		if err != nil {
+			return nil, err
+		}
+		and = append(and, pred)
+		if !p.consume("&") {
+			break
+		}
+	}
+	if len(and) == 1 {
+		return and[0], nil
+	}
+	return and, nil
+}
+
+func (p *parser) parsePredNot() (predicate, error) {
+	if p.consume("!") {
+		pred, err := p.parsePredNot()
+		if err != nil {
+			return nil, err
+		}
+		return notPredicate{p: pred}, nil
+	}
+	if p.consume("(") {
+		pred, err := p.parsePredOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consume(")") {
+			return nil, p.errorf("missing )")
+		}
+		return pred, nil
+	}
+	return p.parseTerm()
+}
+
+func (p *parser) parseTerm() (predicate, error) {
+	p.skipSpaces()
+	start := p.pos
+	for p.pos < len(p.expr) && (isKeyByte(p.expr[p.pos])) {
+		p.pos++
+	}
+	key := p.expr[start:p.pos]
+	if key == "" {
+		return nil, p.errorf("missing key")
+	}
+	if !p.consume(":") {
+		return nil, p.errorf("missing : after %q", key)
+	}
+	p.skipSpaces()
+	value, isRegexp, err := p.parseValue()
+	if err != nil {
+		return nil, err
+	}
+	pred, err := newPredicate(key, value, isRegexp)
+	if err != nil {
+		p.pos = start
+		return nil, p.errorf("%v", err)
+	}
+	return pred, nil
+}
+
+func isKeyByte(c byte) bool {
+	return c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c == '_'
+}
+
+// parseValue parses a bare word, a double-quoted string or a regular
+// expression enclosed in slashes.
+func (p *parser) parseValue() (value string, isRegexp bool, err error) {
+	if p.pos >= len(p.expr) {
+		return "", false, p.errorf("missing value")
+	}
+	switch p.expr[p.pos] {
+	case '"':
+		s, err := strconv.QuotedPrefix(p.expr[p.pos:])
+		if err != nil {
+			return "", false, p.errorf("invalid string")
+		}
+		p.pos += len(s)
+		v, err := strconv.Unquote(s)
+		if err != nil {
+			return "", false, p.errorf("invalid string")
+		}
+		return v, false, nil
+	case '/':
+		var b strings.Builder
+		for i := p.pos + 1; i < len(p.expr); i++ {
+			switch c := p.expr[i]; {
+			case c == '/':
+				p.pos = i + 1
+				return b.String(), true, nil
+			case c == '\\' && i+1 < len(p.expr) && p.expr[i+1] == '/':
+				b.WriteByte('/')
+				i++
+			default:
+				b.WriteByte(c)
+			}
+		}
+		return "", false, p.errorf("missing / of the regular expression")
+	}
+	start := p.pos
+	for p.pos < len(p.expr) {
+		r, size := utf8.DecodeRuneInString(p.expr[p.pos:])
+		if unicode.IsSpace(r) || strings.ContainsRune("[]()&|!", r) {
+			break
+		}
+		p.pos += size
+	}
+	if p.pos == start {
+		return "", false, p.errorf("missing value")
+	}
+	return p.expr[start:p.pos], false, nil
+}
@@ -0,0 +1,264 @@
+package pattern
+
+import (
+	"strings"
+
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// Pattern represents a compiled pattern over token sequences.
+type Pattern struct {
+	expr  string
+	root  node
+	names []string
+}
+
+// Compile parses a pattern and returns it.
+func Compile(expr string) (*Pattern, error) {
+	p := parser{expr: expr}
+	root, err := p.parse()
+	if err != nil {
+		return nil, err
+	}
+	return &Pattern{
+		expr:  expr,
+		root:  root,
+		names: p.names,
+	}, nil
+}
+
+// MustCompile is like Compile but panics if the pattern cannot be parsed.
+func MustCompile(expr string) *Pattern {
+	p, err := Compile(expr)
+	if err != nil {
+		panic(err)
+	}
+	return p
+}
+
+// String returns the source text of the pattern.
+func (p *Pattern) String() string {
+	return p.expr
+}
+
+// NumGroups returns the number of the capturing groups.
+func (p *Pattern) NumGroups() int {
+	return len(p.names)
+}
+
+// GroupNames returns the names of the capturing groups. The name of the
+// whole match and the unnamed groups are empty.
+func (p *Pattern) GroupNames() []string {
+	return append([]string{""}, p.names...)
+}
+
+// Group represents a matched span of tokens.
+type Group struct {
+	Name string
+	// Start and End are the indexes of the tokens, or -1 if the group does
+	// not participate in the match.
+	Start  int
+	End    int
+	Tokens []tokenizer.Token
+}
+
+// Surface returns the surface of the tokens in the group.
+func (g Group) Surface() string {
+	var b strings.Builder
+	for _, v := range g.Tokens {
+		b.WriteString(v.Surface)
+	}
+	return b.String()
+}
+
+// Match represents a match of a pattern. The first group is the whole match
+// and the rest are the capturing groups in the order of their left
+// parentheses.
+type Match []Group
+
+// Group returns the group of the name.
+func (m Match) Group(name string) (Group, bool) {
+	for _, v := range m[1:] {
+		if v.Name != "" && v.Name == name {
+			return v, true
+		}
+	}
+	return Group{}, false
+}
+
+// MatchTokens reports whether the pattern matches the whole tokens.
+func (p *Pattern) MatchTokens(tokens []tokenizer.Token) bool {
+	m := p.newMatcher(tokens)
+	return p.root.match(m, 0, func(i int) bool {
+		return i == len(tokens)
+	})
+}
+
+// Find returns the leftmost match in the tokens.
+func (p *Pattern) Find(tokens []tokenizer.Token) (Match, bool) {
+	ms := p.find(tokens, 1)
+	if len(ms) == 0 {
+		return nil, false
+	}
+	return ms[0], true
+}
+
+// FindAll returns the successive non-overlapping matches in the tokens.
+// If n >= 0, it returns at most n matches.
+func (p *Pattern) FindAll(tokens []tokenizer.Token, n int) []Match {
+	return p.find(tokens, n)
+}
+
+func (p *Pattern) find(tokens []tokenizer.Token, n int) []Match {
+	var ret []Match
+	m := p.newMatcher(tokens)
+	for start := 0; start <= len(tokens) && (n < 0 || len(ret) < n); {
+		if start < len(tokens) && tokens[start].Class == tokenizer.DUMMY {
+			start++
+			continue
+		}
+		for i := range m.caps {
+			m.caps[i] = -1
+		}
+		end := -1
+		if !p.root.match(m, start, func(i int) bool {
+			end = i
+			return true
+		}) {
+			start++
+			continue
+		}
+		ret = append(ret, p.newMatch(tokens, start, end, m.caps))
+		if end > start {
+			start = end
+		} else {
+			start++
+		}
+	}
+	return ret
+}
+
+func (p *Pattern) newMatcher(tokens []tokenizer.Token) *matcher {
+	ret := &matcher{
+		tokens: tokens,
+		caps:   make([]int, 2*(len(p.names)+1)),
+	}
+	for i := range ret.caps {
+		ret.caps[i] = -1
+	}
+	return ret
+}
+
+func (p *Pattern) newMatch(tokens []tokenizer.Token, start, end int, caps []int) Match {
+	ret := make(Match, 0, len(p.names)+1)
+	ret = append(ret, Group{Start: start, End: end, Tokens: tokens[start:end]})
+	for i, name := range p.names {
+		g := Group{Name: name, Start: caps[2*(i+1)], End: caps[2*(i+1)+1]}
+		if g.Start < 0 || g.End < 0 {
+			g.Start, g.End = -1, -1
+		} else {
+			g.Tokens = tokens[g.Start:g.End]
+		}
+		ret = append(ret, g)
+	}
+	return ret
+}
+
+type matcher struct {
+	tokens []tokenizer.Token
+	caps   []int
+}
+
+// node represents a node of a pattern. match matches the node at the i-th
+// token and calls k with the end of the match to match the rest. It returns
+// true if k returns true for some match of the node.
+type node interface {
+	match(m *matcher, i int, k func(int) bool) bool
+}
+
+type tokenNode struct {
+	pred predicate
+}
+
+func (n tokenNode) match(m *matcher, i int, k func(int) bool) bool {
+	if i >= len(m.tokens) || m.tokens[i].Class == tokenizer.DUMMY {
+		return false
+	}
+	return n.pred.match(m.tokens[i]) && k(i+1)
+}
+
+type concatNode []node
+
+func (n concatNode) match(m *matcher, i int, k func(int) bool) bool {
+	if len(n) == 0 {
+		return k(i)
+	}
+	return n[0].match(m, i, func(j int) bool {
+		return n[1:].match(m, j, k)
+	})
+}
+
+type altNode []node
+
+func (n altNode) match(m *matcher, i int, k func(int) bool) bool {
+	for _, v := range n {
+		if v.match(m, i, k) {
+			return true
+		}
+	}
+	return false
+}
+
+type groupNode struct {
+	sub   node
+	index int
+}
+
+func (n *groupNode) match(m *matcher, i int, k func(int) bool) bool {
+	start, end := m.caps[2*n.index], m.caps[2*n.index+1]
+	if n.sub.match(m, i, func(j int) bool {
+		s, e := m.caps[2*n.index], m.caps[2*n.index+1]
+		m.caps[2*n.index], m.caps[2*n.index+1] = i, j
+		if k(j) {
+			return true
+		}
+		m.caps[2*n.index], m.caps[2*n.index+1] = s, e
+		return false
+	}) {
+		return true
+	}
+	m.caps[2*n.index], m.caps[2*n.index+1] = start, end
+	return false
+}
+
+type repeatNode struct {
+	sub  node
+	min  int
+	max  int // -1 if unbounded.
+	lazy bool
+}
+
+func (n *repeatNode) match(m *matcher, i int, k func(int) bool) bool {
+	return n.repeat(m, i, 0, k)
+}
+
+func (n *repeatNode) repeat(m *matcher, i, count int, k func(int) bool) bool {
+	more := func() bool {
+		if n.max >= 0 && count >= n.max {
+			return false
+		}
+		return n.sub.match(m, i, func(j int) bool {
+			if j == i && count >= n.min {
+				return false // an empty iteration never ends.
+			}
+			return n.repeat(m, j, count+1, k)
+		})
+	}
+	if count < n.min {
+		return more()
+	}
+	if n.lazy {
+		return k(i) || more()
+	}
+	return more() || k(i)
+}
@@ -0,0 +1,156 @@
+package pattern
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+const testDictPath = "../testdata/ipa.dict"
+
+func testTokens(t *testing.T, input string) []tokenizer.Token {
+	t.Helper()
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	return tz.Tokenize(input)
+}
+
+func surfaces(ms []Match) []string {
+	var ret []string
+	for _, v := range ms {
+		ret = append(ret, v[0].Surface())
+	}
+	return ret
+}
+
+func TestPattern_FindAll(t *testing.T) {
+	tokens := testTokens(t, "人魚は、南の方の海にばかり棲んでいるのではありません。")
+	testdata := []struct {
+		pattern string
+		want    []string
+	}{
+		{pattern: `[pos:名詞]+ [surface:の] [pos:名詞]`, want: []string{"南の方"}},
+		{pattern: `[pos:名詞] (?:[surface:の] [pos:名詞])+`, want: []string{"南の方の海"}},
+		{pattern: `[pos:助動詞 & !base:ます]`, want: []string{"で", "ん"}},
+		{pattern: `[reading:/^ウ/]`, want: []string{"海"}},
+		{pattern: `[pos:/^動詞-(自立|非自立)/]`, want: []string{"棲ん", "いる", "あり"}},
+		{pattern: `[pos:名詞-*-一般]`, want: []string{"方", "の"}},
+		{pattern: `[pos:助詞 | pos:助動詞]{2,}`, want: []string{"にばかり", "では", "ません"}},
+		{pattern: `[surface:"南"] | [surface:海]`, want: []string{"南", "海"}},
+		{pattern: `[pos:記号]`, want: []string{"、", "。"}},
+		{pattern: `[pos:動詞] [pos:形容詞]`, want: nil},
+	}
+	for _, v := range testdata {
+		t.Run(v.pattern, func(t *testing.T) {
+			p, err := Compile(v.pattern)
+			if err != nil {
+				t.Fatalf("unexpected error, %v", err)
+			}
+			if got := surfaces(p.FindAll(tokens, -1)); !reflect.DeepEqual(got, v.want) {
+				t.Errorf("got %q, want %q", got, v.want)
+			}
+		})
+	}
+}
+
+func TestPattern_Find(t *testing.T) {
+	tokens := testTokens(t, "人魚は、南の方の海にばかり棲んでいるのではありません。")
+	t.Run("greedy", func(t *testing.T) {
+		m, ok := MustCompile(`[]+ [surface:の]`).Find(tokens)
+		if !ok {
+			t.Fatal("expected a match")
+		}
+		if got, want := m[0].Surface(), "人魚は、南の方の海にばかり棲んでいるの"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+	t.Run("lazy", func(t *testing.T) {
+		m, ok := MustCompile(`[]+? [surface:の]`).Find(tokens)
+		if !ok {
+			t.Fatal("expected a match")
+		}
+		if got, want := m[0].Surface(), "人魚は、南の"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+	t.Run("captures", func(t *testing.T) {
+		p := MustCompile(`(?<head>[pos:名詞]) ([surface:の] [pos:名詞])+ ([surface:は])?`)
+		if got, want := p.NumGroups(), 3; got != want {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		m, ok := p.Find(tokens)
+		if !ok {
+			t.Fatal("expected a match")
+		}
+		if got, want := m[0].Surface(), "南の方の海"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+		g, ok := m.Group("head")
+		if !ok {
+			t.Fatal("expected a group")
+		}
+		if got, want := g.Surface(), "南"; got != want || g.Start != 3 || g.End != 4 {
+			t.Errorf("got %q [%d:%d], want %q [3:4]", got, g.Start, g.End, want)
+		}
+		if got, want := m[2].Surface(), "の海"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+		if m[3].Start != -1 || m[3].End != -1 || m[3].Tokens != nil {
+			t.Errorf("unexpected group, %+v", m[3])
+		}
+	})
+	t.Run("no match", func(t *testing.T) {
+		if _, ok := MustCompile(`[surface:山]`).Find(tokens); ok {
+			t.Error("unexpected match")
+		}
+	})
+}
+
+func TestPattern_MatchTokens(t *testing.T) {
+	tokens := testTokens(t, "赤い蝋燭")
+	testdata := []struct {
+		pattern string
+		want    bool
+	}{
+		{pattern: `[]*`, want: true},
+		{pattern: `[pos:形容詞] [pos:名詞]`, want: true},
+		{pattern: `[pos:形容詞]`, want: false},
+		{pattern: `[pos:名詞]{2}`, want: false},
+		{pattern: `[]{1,2}`, want: true},
+	}
+	for _, v := range testdata {
+		if got := MustCompile(v.pattern).MatchTokens(tokens); got != v.want {
+			t.Errorf("%s: got %v, want %v", v.pattern, got, v.want)
+		}
+	}
+}
+
+func TestCompile_Error(t *testing.T) {
+	for _, v := range []string{
+		"",
+		"[pos:名詞",
+		"[piyo:名詞]",
+		"[pos:名詞]**",
+		"[surface:/(/]",
+		"[surface:/a]",
+		"[pos:名詞]{3,1}",
+		"[pos:名詞]{x}",
+		"(?<x>[]",
+		"[surface:]",
+		"[pos:名詞--一般]",
+		"[] )",
+		"[] |",
+	} {
+		if _, err := Compile(v); err == nil {
+			t.Errorf("%q: expected error", v)
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package pattern
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/ikawaha/kagome/v2/filter"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// predicate represents a condition on a token.
+type predicate interface {
+	match(t tokenizer.Token) bool
+}
+
+type anyPredicate struct{}
+
+func (anyPredicate) match(tokenizer.Token) bool {
+	return true
+}
+
+type notPredicate struct {
+	p predicate
+}
+
+func (p notPredicate) match(t tokenizer.Token) bool {
+	return !p.p.match(t)
+}
+
+type andPredicate []predicate
+
+func (p andPredicate) match(t tokenizer.Token) bool {
+	for _, v := range p {
+		if !v.match(t) {
+			return false
+		}
+	}
+	return true
+}
+
+type orPredicate []predicate
+
+func (p orPredicate) match(t tokenizer.Token) bool {
+	for _, v := range p {
+		if v.match(t) {
+			return true
+		}
+	}
+	return false
+}
+
+// fieldPredicate tests a string field of a token.
+type fieldPredicate struct {
+	field func(t tokenizer.Token) (string, bool)
+	value string
+	re    *regexp.Regexp
+}
+
+func (p fieldPredicate) match(t tokenizer.Token) bool {
+	v, ok := p.field(t)
+	if !ok {
+		return false
+	}
+	if p.re != nil {
+		return p.re.MatchString(v)
+	}
+	return v == p.value
+}
+
+// posPredicate tests the part-of-speech of a token.
+type posPredicate struct {
+	pos *filter.POSFilter
+	re  *regexp.Regexp
+}
+
+func (p posPredicate) match(t tokenizer.Token) bool {
+	if p.re != nil {
+		return p.re.MatchString(strings.Join(t.POS(), "-"))
+	}
+	return p.pos.Match(t.POS())
+}
+
+func surfaceField(t tokenizer.Token) (string, bool) {
+	return t.Surface, true
+}
+
+func newPredicate(key, value string, isRegexp bool) (predicate, error) {
+	var re *regexp.Regexp
+	if isRegexp {
+		var err error
+		if re, err = regexp.Compile(value); err != nil {
+			return nil, err
+		}
+	}
+	var field func(t tokenizer.Token) (string, bool)
+	switch key {
+	case "surface":
+		field = surfaceField
+	case "base", "base_form":
+		field = tokenizer.Token.BaseForm
+	case "reading":
+		field = tokenizer.Token.Reading
+	case "pron", "pronunciation":
+		field = tokenizer.Token.Pronunciation
+	case "pos":
+		if re != nil {
+			return posPredicate{re: re}, nil
+		}
+		p, err := filter.ParsePOSPattern(value)
+		if err != nil {
+			return nil, err
+		}
+		return posPredicate{pos: filter.NewPOSFilter(p)}, nil
+	default:
+		return nil, fmt.Errorf("unknown key, %q", key)
+	}
+	return fieldPredicate{field: field, value: value, re: re}, nil
+}
@@ -0,0 +1,149 @@
+package lattice
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+const zeroWidthJoiner = '\u200d'
+
+// graphemeLen returns the byte length of the first extended grapheme cluster
+// of the string. It follows the rules of UAX #29 except the prepend rule,
+// e.g. an emoji with a skin tone modifier, a ZWJ sequence of emoji, a flag
+// of regional indicators and a character with combining marks or variation
+// selectors are single clusters.
+// see. https://unicode.org/reports/tr29/#Grapheme_Cluster_Boundary_Rules
+func graphemeLen(s string) int {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 {
+		return 0
+	}
+	if r == '\r' {
+		if len(s) > 1 && s[1] == '\n' {
+			return 2
+		}
+		return 1
+	}
+	if isControl(r) {
+		return size
+	}
+	var (
+		pict = isPictographic(r)
+		ri   = isRegionalIndicator(r) // a regional indicator waiting for its pair
+		prev = r
+	)
+	for size < len(s) {
+		c, w := utf8.DecodeRuneInString(s[size:])
+		switch {
+		case isExtend(c) || c == zeroWidthJoiner || unicode.Is(unicode.Mc, c):
+		case prev == zeroWidthJoiner && pict && isPictographic(c):
+		case ri && isRegionalIndicator(c):
+			ri = false
+		case hangulJoins(prev, c):
+		default:
+			return size
+		}
+		prev = c
+		size += w
+	}
+	return size
+}
+
+func isControl(r rune) bool {
+	return unicode.Is(unicode.Cc, r) || r == '\u2028' || r == '\u2029'
+}
+
+// isExtend reports whether the rune extends the previous one. The variation
+// selectors are the nonspacing marks.
+func isExtend(r rune) bool {
+	switch {
+	case unicode.In(r, unicode.Mn, unicode.Me):
+		return true
+	case r == '\u200c': // zero width non-joiner
+		return true
+	case r == 'ﾞ' || r == 'ﾟ': // halfwidth (semi-)voiced sound marks
+		return true
+	case r >= 0x1F3FB && r <= 0x1F3FF: // emoji modifiers (skin tones)
+		return true
+	case r >= 0xE0020 && r <= 0xE007F: // tags
+		return true
+	}
+	return false
+}
+
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
+
+// isPictographic approximates the Extended_Pictographic property.
+func isPictographic(r rune) bool {
+	switch {
+	case r >= 0x1F000 && r <= 0x1FAFF:
+		return !isRegionalIndicator(r) && !(r >= 0x1F3FB && r <= 0x1F3FF)
+	case r >= 0x2190 && r <= 0x21FF, r >= 0x2300 && r <= 0x23FF, r >= 0x2600 && r <= 0x27BF, r >= 0x2B00 && r <= 0x2BFF:
+		return true
+	}
+	switch r {
+	case 0x00A9, 0x00AE, 0x203C, 0x2049, 0x2122, 0x2139, 0x3030, 0x303D, 0x3297, 0x3299:
+		return true
+	}
+	return false
+}
+
+// hangul syllable types.
+const (
+	hangulNone = iota
+	hangulL
+	hangulV
+	hangulT
+	hangulLV
+	hangulLVT
+)
+
+func hangulType(r rune) int {
+	switch {
+	case r >= 0x1100 && r <= 0x115F, r >= 0xA960 && r <= 0xA97C:
+		return hangulL
+	case r >= 0x1160 && r <= 0x11A7, r >= 0xD7B0 && r <= 0xD7C6:
+		return hangulV
+	case r >= 0x11A8 && r <= 0x11FF, r >= 0xD7CB && r <= 0xD7FB:
+		return hangulT
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return hangulLV
+		}
+		return hangulLVT
+	}
+	return hangulNone
+}
+
+// hangulJoins reports whether the jamo and the syllables are in a cluster.
+func hangulJoins(prev, r rune) bool {
+	switch p, c := hangulType(prev), hangulType(r); p {
+	case hangulL:
+		return c == hangulL || c == hangulV || c == hangulLV || c == hangulLVT
+	case hangulLV, hangulV:
+		return c == hangulV || c == hangulT
+	case hangulLVT, hangulT:
+		return c == hangulT
+	}
+	return false
+}
+
+// graphemeBoundaries returns the boundaries of the extended grapheme
+// clusters of the string. The i-th element is true if the i-th byte starts
+// a cluster or i == len(s). It reuses the buffer if possible.
+func graphemeBoundaries(buf []bool, s string) []bool {
+	if cap(buf) < len(s)+1 {
+		buf = make([]bool, len(s)+1)
+	}
+	buf = buf[:len(s)+1]
+	for i := range buf {
+		buf[i] = false
+	}
+	for i := 0; i < len(s); i += graphemeLen(s[i:]) {
+		buf[i] = true
+	}
+	buf[len(s)] = true
+	return buf
+}
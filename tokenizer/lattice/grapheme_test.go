@@ -0,0 +1,46 @@
+package lattice
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_GraphemeLen(t *testing.T) {
+	testdata := []struct {
+		name  string
+		input string
+		want  string
+	}{
+		{name: "empty", input: "", want: ""},
+		{name: "ascii", input: "ab", want: "a"},
+		{name: "crlf", input: "\r\nx", want: "\r\n"},
+		{name: "control", input: "\n\u0301", want: "\n"},
+		{name: "combining mark", input: "e\u0301x", want: "e\u0301"},
+		{name: "combining voiced sound mark", input: "か\u3099き", want: "か\u3099"},
+		{name: "halfwidth voiced sound mark", input: "ﾊﾞｲｸ", want: "ﾊﾞ"},
+		{name: "variation selector", input: "葛\U000E0100城", want: "葛\U000E0100"},
+		{name: "emoji presentation", input: "❤\ufe0f!", want: "❤\ufe0f"},
+		{name: "skin tone", input: "👍🏽👍", want: "👍🏽"},
+		{name: "zwj sequence", input: "👨\u200d👩\u200d👧です", want: "👨\u200d👩\u200d👧"},
+		{name: "zwj not followed by pictograph", input: "a\u200dあ", want: "a\u200d"},
+		{name: "flags", input: "🇯🇵🇺🇸", want: "🇯🇵"},
+		{name: "subdivision flag", input: "🏴\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F!", want: "🏴\U000E0067\U000E0062\U000E0065\U000E006E\U000E0067\U000E007F"},
+		{name: "hangul jamo", input: "각가", want: "각"},
+		{name: "invalid", input: "\xffa", want: "\xff"},
+	}
+	for _, v := range testdata {
+		t.Run(v.name, func(t *testing.T) {
+			if got := v.input[:graphemeLen(v.input)]; got != v.want {
+				t.Errorf("got %+q, want %+q", got, v.want)
+			}
+		})
+	}
+}
+
+func Test_GraphemeBoundaries(t *testing.T) {
+	got := graphemeBoundaries(make([]bool, 100), "a👍🏽b")
+	want := []bool{true, true, false, false, false, false, false, false, false, true, true}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -43,6 +43,7 @@ type Lattice struct {
 	list   [][]*Node
 	dic    *dict.Dict
 	udic   *dict.UserDict
+	bounds []bool // boundaries of the grapheme clusters of the input
 }
 
 // New returns a new lattice.
@@ -81,7 +82,7 @@ func (la *Lattice) addNode(pos, id, position, start int, class NodeClass, surfac
 		m = la.dic.Morphs[id]
 	case UNKNOWN:
 		m = la.dic.UnkDict.Morphs[id]
-	case USER:
+	case USER, SYNTHETIC:
 		// use default cost
 	}
 	n := nodePool.Get()
@@ -97,9 +98,27 @@ func (la *Lattice) addNode(pos, id, position, start int, class NodeClass, surfac
 	la.list[p] = append(la.list[p], n)
 }
 
+// Span represents a span of the input which is built as a single node of
+// the SYNTHETIC class.
+type Span struct {
+	ID    int // the ID of the node
+	Start int // byte offset
+	End   int // byte offset
+}
+
 // Build builds a lattice from the inputs.
-// nolint: gocyclo
 func (la *Lattice) Build(inp string) {
+	la.BuildWithSpans(inp, nil)
+}
+
+// BuildWithSpans builds a lattice from the inputs. Each span is built as
+// a single node, and no other node starts inside or crosses over the span.
+// The spans must be sorted by their offsets and must not overlap.
+// The other nodes start and end at the boundaries of the extended grapheme
+// clusters, so that a cluster such as a ZWJ sequence of emoji is never
+// split apart.
+// nolint: gocyclo
+func (la *Lattice) BuildWithSpans(inp string, spans []Span) {
 	rc := utf8.RuneCountInString(inp)
 	la.Input = inp
 	if cap(la.list) < rc+2 {
@@ -110,14 +129,41 @@ func (la *Lattice) Build(inp string) {
 	la.addNode(0, BosEosID, 0, 0, DUMMY, inp[0:0])
 	la.addNode(rc+1, BosEosID, len(inp), rc, DUMMY, inp[rc:rc])
 
+	la.bounds = graphemeBoundaries(la.bounds, inp)
+	for _, sp := range spans {
+		la.bounds[sp.Start], la.bounds[sp.End] = true, true
+	}
+
 	runePos := -1
 	for pos, ch := range inp {
 		runePos++
 		anyMatches := false
 
+		// (0) SPANS
+		for len(spans) > 0 && spans[0].End <= pos {
+			spans = spans[1:]
+		}
+		limit := len(inp) // nodes must not cross over the next span.
+		if len(spans) > 0 {
+			if sp := spans[0]; sp.Start == pos {
+				la.addNode(runePos, sp.ID, pos, runePos, SYNTHETIC, inp[sp.Start:sp.End])
+				continue
+			} else if sp.Start < pos {
+				continue
+			} else {
+				limit = sp.Start
+			}
+		}
+		if !la.bounds[pos] {
+			continue // inside a grapheme cluster
+		}
+
 		// (1) USER DIC
 		if la.udic != nil {
 			la.udic.Index.CommonPrefixSearchCallback(inp[pos:], func(id, l int) {
+				if pos+l > limit || !la.bounds[pos+l] {
+					return
+				}
 				la.addNode(runePos, id, pos, runePos, USER, inp[pos:pos+l])
 				if !anyMatches {
 					anyMatches = true
@@ -129,6 +175,9 @@ func (la *Lattice) Build(inp string) {
 		}
 		// (2) KNOWN DIC
 		la.dic.Index.CommonPrefixSearchCallback(inp[pos:], func(id, l int) {
+			if pos+l > limit || !la.bounds[pos+l] {
+				return
+			}
 			la.addNode(runePos, id, pos, runePos, KNOWN, inp[pos:pos+l])
 			if !anyMatches {
 				anyMatches = true
@@ -137,32 +186,22 @@ func (la *Lattice) Build(inp string) {
 		// (3) UNKNOWN DIC
 		class := la.dic.CharacterCategory(ch)
 		if !anyMatches || la.dic.InvokeList[int(class)] {
-			var endPos int
-			if ch != utf8.RuneError {
-				endPos = pos + utf8.RuneLen(ch)
-			} else {
-				endPos = pos + 1
-			}
+			// prev is the end of the word with one cluster truncated.
+			prev, endPos := pos, la.nextBound(pos)
 			unkWordLen := 1
 			if la.dic.GroupList[int(class)] {
-				for i, w, size := endPos, 0, len(inp); i < size; i += w {
-					var c rune
-					c, w = utf8.DecodeRuneInString(inp[i:])
+				for endPos < limit {
+					c, _ := utf8.DecodeRuneInString(inp[endPos:])
 					if la.dic.CharacterCategory(c) != class {
 						break
 					}
-					endPos += w
+					prev, endPos = endPos, la.nextBound(endPos)
 					unkWordLen++
 					if unkWordLen >= maximumUnknownWordLength {
 						break
 					}
 				}
 			}
-
-			prev := pos
-			if c, size := utf8.DecodeLastRuneInString(inp[pos:endPos]); c != utf8.RuneError {
-				prev = endPos - size
-			}
 			id := la.dic.UnkDict.Index[int32(class)]
 			dup := la.dic.UnkDict.IndexDup[int32(class)]
 			for x := 0; x < int(dup)+1; x++ {
@@ -176,6 +215,16 @@ func (la *Lattice) Build(inp string) {
 	}
 }
 
+// nextBound returns the next boundary of the grapheme clusters after the
+// position.
+func (la *Lattice) nextBound(pos int) int {
+	pos++
+	for pos < len(la.bounds) && !la.bounds[pos] {
+		pos++
+	}
+	return pos
+}
+
 // String returns a debug string of a lattice.
 func (la *Lattice) String() string {
 	str := ""
@@ -221,7 +270,7 @@ func (la *Lattice) Forward(m TokenizeMode) {
 			}
 			for j, n := range prevList {
 				var c int16
-				if n.Class != USER && target.Class != USER {
+				if !noConnection(n.Class) && !noConnection(target.Class) {
 					c = la.dic.Connection.At(int(n.Right), int(target.Left))
 				}
 				totalCost := int64(c) + int64(target.Weight) + int64(n.Cost)
@@ -240,6 +289,12 @@ func (la *Lattice) Forward(m TokenizeMode) {
 	}
 }
 
+// noConnection reports whether the nodes of the class have no connection
+// costs.
+func noConnection(c NodeClass) bool {
+	return c == USER || c == SYNTHETIC
+}
+
 // Backward runs backward algorithm of the Viterbi.
 func (la *Lattice) Backward(m TokenizeMode) {
 	size := len(la.list)
@@ -251,21 +306,22 @@ func (la *Lattice) Backward(m TokenizeMode) {
 			la.Output = append(la.Output, p)
 			continue
 		}
-		runeLen := utf8.RuneCountInString(p.Surface)
-		stack := make([]*Node, 0, runeLen)
-		i := 0
-		for k, r := range p.Surface {
+		// split the unknown word into the grapheme clusters.
+		stack := make([]*Node, 0, utf8.RuneCountInString(p.Surface))
+		for k, i := 0, 0; k < len(p.Surface); {
+			w := graphemeLen(p.Surface[k:])
 			stack = append(stack, &Node{
 				ID:       p.ID,
 				Start:    p.Start + i,
 				Class:    DUMMY,
-				Surface:  string(r),
+				Surface:  p.Surface[k : k+w],
 				Position: p.Position + k,
 			})
-			i++
+			k += w
+			i += utf8.RuneCountInString(p.Surface[k-w : k])
 		}
-		for j, end := 0, len(stack); j < end; j++ {
-			la.Output = append(la.Output, stack[runeLen-1-j])
+		for j := len(stack) - 1; j >= 0; j-- {
+			la.Output = append(la.Output, stack[j])
 		}
 	}
 }
@@ -299,6 +355,8 @@ func posFeature(d *dict.Dict, u *dict.UserDict, t *Node) string {
 		}
 	case USER:
 		ret = append(ret, u.Contents[t.ID].Pos)
+	case SYNTHETIC:
+		ret = append(ret, SYNTHETIC.String())
 	}
 	if len(ret) == 0 {
 		return "---"
@@ -371,7 +429,7 @@ func (la *Lattice) Dot(w io.Writer) {
 	}
 	for _, e := range edges {
 		var c int16
-		if e.from.Class != USER && e.to.Class != USER {
+		if !noConnection(e.from.Class) && !noConnection(e.to.Class) {
 			c = la.dic.Connection.At(int(e.from.Right), int(e.to.Left))
 		}
 		_, l := bests[e.from]
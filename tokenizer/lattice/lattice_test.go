@@ -2,6 +2,7 @@ package lattice
 
 import (
 	"bytes"
+	"reflect"
 	"testing"
 	"unicode/utf8"
 
@@ -365,3 +366,41 @@ func Test_Backward(t *testing.T) {
 		la.Backward(m)
 	}
 }
+
+func Test_LatticeGraphemeClusters(t *testing.T) {
+	testdata := []struct {
+		mode  TokenizeMode
+		input string
+		want  []string
+	}{
+		{mode: Normal, input: "家族👨\u200d👩\u200d👧です", want: []string{"家族", "👨\u200d👩\u200d👧", "です"}},
+		{mode: Normal, input: "か\u3099", want: []string{"か\u3099"}},
+		{mode: Extended, input: "👨\u200d👩\u200d👧👍🏽", want: []string{"👨\u200d👩\u200d👧", "👍🏽"}},
+		{mode: Extended, input: "ﾎﾟﾎﾟﾋﾟ", want: []string{"ﾎﾟ", "ﾎﾟ", "ﾋﾟ"}},
+		{mode: Extended, input: "❤\ufe0f♪", want: []string{"❤\ufe0f", "♪"}},
+	}
+	for _, v := range testdata {
+		la := New(ipa.Dict(), nil)
+		la.Build(v.input)
+		la.Forward(v.mode)
+		la.Backward(v.mode)
+		var got []string
+		var start, position int
+		for i := len(la.Output) - 1; i >= 0; i-- {
+			n := la.Output[i]
+			if n.ID == BosEosID {
+				continue
+			}
+			if n.Start != start || n.Position != position {
+				t.Errorf("input %+q: %+q at (%d, %d), want (%d, %d)", v.input, n.Surface, n.Position, n.Start, position, start)
+			}
+			start += utf8.RuneCountInString(n.Surface)
+			position += len(n.Surface)
+			got = append(got, n.Surface)
+		}
+		if !reflect.DeepEqual(got, v.want) {
+			t.Errorf("input %+q: got %+q, want %+q", v.input, got, v.want)
+		}
+		la.Free()
+	}
+}
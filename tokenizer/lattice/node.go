@@ -13,6 +13,7 @@ const (
 	KNOWN
 	UNKNOWN
 	USER
+	SYNTHETIC
 )
 
 // NodeClass represents a node type.
@@ -29,6 +30,8 @@ func (nc NodeClass) String() string {
 		return "UNKNOWN"
 	case USER:
 		return "USER"
+	case SYNTHETIC:
+		return "SYNTHETIC"
 	}
 	return "UNDEF"
 }
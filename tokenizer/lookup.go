@@ -0,0 +1,156 @@
+package tokenizer
+
+import (
+	"fmt"
+	"unicode/utf8"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome-dict/dict/trie"
+)
+
+// LookupMode represents how to search the dictionary indexes.
+type LookupMode int
+
+const (
+	// Exact finds the entries whose surface is equal to the input.
+	Exact LookupMode = iota + 1
+	// CommonPrefix finds the entries whose surface is a prefix of the input.
+	CommonPrefix
+	// Predictive finds the entries whose surface starts with the input.
+	Predictive
+)
+
+// String returns a string representation of a lookup mode.
+func (m LookupMode) String() string {
+	switch m {
+	case Exact:
+		return "exact"
+	case CommonPrefix:
+		return "common-prefix"
+	case Predictive:
+		return "predictive"
+	}
+	return fmt.Sprintf("unknown lookup mode (%d)", m)
+}
+
+// Entry represents a dictionary entry found by a lookup.
+// LeftID, RightID and Cost are those of the system dictionary, so they are
+// set only for the entries of the KNOWN class. The entries of the user
+// dictionary have no connection IDs nor costs (the tokenizer connects them
+// at no cost), and these fields are always 0 for them, which is not a real
+// value.
+type Entry struct {
+	Token
+	LeftID  int // only for KNOWN
+	RightID int // only for KNOWN
+	Cost    int // only for KNOWN
+}
+
+// Lookup searches the user dictionary and the system dictionary indexes and
+// returns the matched entries. The entries of the user dictionary come first.
+// If limit > 0, it returns at most limit entries.
+func (t Tokenizer) Lookup(input string, mode LookupMode, limit int) []Entry {
+	var ret []Entry
+	add := func(class TokenClass, id int, surface string) bool {
+		if limit > 0 && len(ret) >= limit {
+			return false
+		}
+		e := Entry{
+			Token: Token{
+				Index:   len(ret),
+				ID:      id,
+				Class:   class,
+				End:     utf8.RuneCountInString(surface),
+				Surface: surface,
+				dict:    t.dict,
+				udict:   t.userDict,
+			},
+		}
+		if class == KNOWN {
+			m := t.dict.Morphs[id]
+			e.LeftID, e.RightID, e.Cost = int(m.LeftID), int(m.RightID), int(m.Weight)
+		}
+		ret = append(ret, e)
+		return true
+	}
+	if t.userDict != nil {
+		lookup(t.userDict.Index, input, mode, func(id int, surface string) bool {
+			return add(USER, id, surface)
+		})
+	}
+	lookup(t.dict.Index, input, mode, func(id int, surface string) bool {
+		return add(KNOWN, id, surface)
+	})
+	return ret
+}
+
+func lookup(idx dict.IndexTable, input string, mode LookupMode, callback func(id int, surface string) bool) {
+	each := func(id int, surface string) bool {
+		for i, dup := id, int(idx.Dup[int32(id)]); i <= id+dup; i++ {
+			if !callback(i, surface) {
+				return false
+			}
+		}
+		return true
+	}
+	switch mode {
+	case Exact:
+		if id, ok := idx.Da.Find(input); ok {
+			each(id, input)
+		}
+	case CommonPrefix:
+		ids, lens := idx.Da.CommonPrefixSearch(input)
+		for i := range ids {
+			if !each(ids[i], input[:lens[i]]) {
+				return
+			}
+		}
+	case Predictive:
+		predictiveSearch(idx.Da, input, each)
+	}
+}
+
+// predictiveSearch traverses the subtree of the double array under the
+// prefix in lexicographic order and calls back with the id and the keyword.
+func predictiveSearch(da trie.DoubleArray, prefix string, callback func(id int, keyword string) bool) {
+	const terminator = 0
+	if len(da) == 0 {
+		return
+	}
+	var q int
+	for i := 0; i < len(prefix); i++ {
+		if prefix[i] == terminator {
+			return
+		}
+		p := q
+		q = int(da[p].Base) + int(prefix[i])
+		if q >= len(da) || int(da[q].Check) != p {
+			return
+		}
+	}
+	key := []byte(prefix)
+	var walk func(p int) bool
+	walk = func(p int) bool {
+		base := int(da[p].Base)
+		for c := 0; c < 256; c++ {
+			q := base + c
+			if q <= 0 || q >= len(da) || int(da[q].Check) != p {
+				continue
+			}
+			if c == terminator {
+				if da[q].Base <= 0 && !callback(int(-da[q].Base), string(key)) {
+					return false
+				}
+				continue
+			}
+			key = append(key, byte(c))
+			ok := walk(q)
+			key = key[:len(key)-1]
+			if !ok {
+				return false
+			}
+		}
+		return true
+	}
+	walk(q)
+}
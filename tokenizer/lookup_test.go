@@ -0,0 +1,105 @@
+package tokenizer
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+)
+
+func Test_LookupModeString(t *testing.T) {
+	testdata := []struct {
+		mode LookupMode
+		want string
+	}{
+		{mode: Exact, want: "exact"},
+		{mode: CommonPrefix, want: "common-prefix"},
+		{mode: Predictive, want: "predictive"},
+		{mode: 0, want: "unknown lookup mode (0)"},
+	}
+	for _, v := range testdata {
+		if got := v.mode.String(); got != v.want {
+			t.Errorf("got %q, want %q", got, v.want)
+		}
+	}
+}
+
+func Test_Lookup(t *testing.T) {
+	udict, err := dict.NewUserDict(testUserDictPath)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tnz, err := New(loadTestDict(t), UserDict(udict))
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	t.Run("exact", func(t *testing.T) {
+		got := tnz.Lookup("ねこ", Exact, 0)
+		if len(got) == 0 {
+			t.Fatal("no entries")
+		}
+		for _, v := range got {
+			if v.Surface != "ねこ" || v.Class != KNOWN {
+				t.Errorf("unexpected entry, %v", v)
+			}
+			m := tnz.dict.Morphs[v.ID]
+			if v.LeftID != int(m.LeftID) || v.RightID != int(m.RightID) || v.Cost != int(m.Weight) {
+				t.Errorf("got %+v, want %+v", v, m)
+			}
+		}
+		if got := tnz.Lookup("ねこねこねこ", Exact, 0); len(got) != 0 {
+			t.Errorf("got %v, want no entries", got)
+		}
+	})
+	t.Run("common prefix", func(t *testing.T) {
+		var got []string
+		for _, v := range tnz.Lookup("関西国際空港", CommonPrefix, 0) {
+			got = append(got, v.Class.String()+":"+v.Surface)
+		}
+		if len(got) == 0 || got[0] != "USER:関西国際空港" {
+			t.Fatalf("got %v, want the user entry first", got)
+		}
+		set := map[string]bool{}
+		for _, v := range got[1:] {
+			set[v] = true
+		}
+		want := map[string]bool{"KNOWN:関": true, "KNOWN:関西": true, "KNOWN:関西国際空港": true}
+		if !reflect.DeepEqual(set, want) {
+			t.Errorf("got %v, want %v", set, want)
+		}
+	})
+	t.Run("predictive", func(t *testing.T) {
+		got := tnz.Lookup("日本経", Predictive, 0)
+		var surfaces []string
+		for _, v := range got {
+			if v.Class == USER {
+				surfaces = append(surfaces, v.Surface)
+				continue
+			}
+			if got := tnz.dict.Index.Search(v.Surface); len(got) == 0 {
+				t.Errorf("surface %q is not in the index", v.Surface)
+			}
+		}
+		if want := []string{"日本経済新聞"}; !reflect.DeepEqual(surfaces, want) {
+			t.Errorf("got %v, want %v", surfaces, want)
+		}
+		var known []string
+		for _, v := range got {
+			if v.Class == KNOWN {
+				known = append(known, v.Surface)
+			}
+		}
+		if !sort.StringsAreSorted(known) {
+			t.Errorf("entries are not sorted, %v", known)
+		}
+		if len(known) == 0 {
+			t.Error("no known entries")
+		}
+	})
+	t.Run("limit", func(t *testing.T) {
+		if got := tnz.Lookup("あ", Predictive, 3); len(got) != 3 {
+			t.Errorf("got %d entries, want 3", len(got))
+		}
+	})
+}
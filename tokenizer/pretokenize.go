@@ -0,0 +1,87 @@
+package tokenizer
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/ikawaha/kagome/v2/tokenizer/lattice"
+)
+
+// AtomicPattern represents a pattern of the spans which are tokenized as
+// single tokens of the SYNTHETIC class before the lattice is built. The
+// tokens have the POS and their surfaces as the base forms.
+type AtomicPattern struct {
+	Regexp *regexp.Regexp
+	POS    []string
+}
+
+// Regular expressions of the built-in atomic patterns.
+var (
+	// URLRegexp matches URLs of http and https. A URL does not end with
+	// a punctuation, e.g. the period of "see https://example.com.".
+	URLRegexp = regexp.MustCompile(`https?://[A-Za-z0-9\-._~:/?#\[\]@!$&'()*+,;=%]*[A-Za-z0-9\-_~/#\[\]@$&'*+=%]`)
+	// EmailRegexp matches email addresses.
+	EmailRegexp = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+	// HashtagRegexp matches hashtags which start with a half-width or
+	// full-width number sign and contain at least one letter.
+	HashtagRegexp = regexp.MustCompile(`[#＃][\p{L}\p{N}_]*\p{L}[\p{L}\p{N}_]*`)
+	// EmojiRegexp matches emoji including the sequences of flags, keycaps,
+	// skin tone modifiers and zero width joiners.
+	EmojiRegexp = regexp.MustCompile(`[\x{1F1E6}-\x{1F1FF}]{2}` +
+		`|[0-9#*]\x{FE0F}?\x{20E3}` +
+		`|(?:[\x{1F000}-\x{1FAFF}]|[\x{2600}-\x{27BF}]\x{FE0F})[\x{FE0F}\x{1F3FB}-\x{1F3FF}]*` +
+		`(?:\x{200D}(?:[\x{1F000}-\x{1FAFF}]|[\x{2600}-\x{27BF}]\x{FE0F}?)[\x{FE0F}\x{1F3FB}-\x{1F3FF}]*)*`)
+)
+
+// Built-in atomic patterns.
+var (
+	URLPattern     = AtomicPattern{Regexp: URLRegexp, POS: []string{"URL"}}
+	EmailPattern   = AtomicPattern{Regexp: EmailRegexp, POS: []string{"メール"}}
+	HashtagPattern = AtomicPattern{Regexp: HashtagRegexp, POS: []string{"ハッシュタグ"}}
+	EmojiPattern   = AtomicPattern{Regexp: EmojiRegexp, POS: []string{"絵文字"}}
+)
+
+// DefaultAtomicPatterns returns the built-in atomic patterns.
+func DefaultAtomicPatterns() []AtomicPattern {
+	return []AtomicPattern{URLPattern, EmailPattern, HashtagPattern, EmojiPattern}
+}
+
+// atomicSpans returns the spans of the input which match the patterns. The
+// ID of a span is the index of the pattern. When the matches overlap, the
+// one which starts first wins, and the longer one wins among the matches
+// which start at the same position.
+func atomicSpans(input string, patterns []AtomicPattern) []lattice.Span {
+	if len(patterns) == 0 {
+		return nil
+	}
+	var ret []lattice.Span
+	for i, p := range patterns {
+		for _, loc := range p.Regexp.FindAllStringIndex(input, -1) {
+			if loc[0] < loc[1] {
+				ret = append(ret, lattice.Span{ID: i, Start: loc[0], End: loc[1]})
+			}
+		}
+	}
+	sort.SliceStable(ret, func(i, j int) bool {
+		if ret[i].Start != ret[j].Start {
+			return ret[i].Start < ret[j].Start
+		}
+		return ret[i].End > ret[j].End
+	})
+	spans := ret[:0]
+	for _, v := range ret {
+		if len(spans) > 0 && v.Start < spans[len(spans)-1].End {
+			continue
+		}
+		spans = append(spans, v)
+	}
+	return spans
+}
+
+// atomicFeatures returns the features of the token made from the span.
+func (t Tokenizer) atomicFeatures(n *lattice.Node) *SyntheticFeatures {
+	return &SyntheticFeatures{
+		POS:      t.atomicPatterns[n.ID].POS,
+		BaseForm: n.Surface,
+	}
+}
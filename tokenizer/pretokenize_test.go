@@ -0,0 +1,142 @@
+package tokenizer
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+)
+
+func Test_AtomicPatterns(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	t.Run("empty pattern", func(t *testing.T) {
+		if _, err := New(d, AtomicPatterns(AtomicPattern{})); err == nil {
+			t.Error("expected error")
+		}
+	})
+	t.Run("built-in patterns", func(t *testing.T) {
+		tnz, err := New(d, OmitBosEos(), AtomicPatterns(DefaultAtomicPatterns()...))
+		if err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		type atom struct {
+			Surface  string
+			POS      []string
+			Position int
+			Start    int
+			End      int
+		}
+		testdata := []struct {
+			input string
+			want  []atom
+		}{
+			{
+				input: "詳細はhttps://example.com/a?b=1&c=2#top。",
+				want: []atom{
+					{Surface: "https://example.com/a?b=1&c=2#top", POS: []string{"URL"}, Position: 9, Start: 3, End: 36},
+				},
+			},
+			{
+				input: "連絡先:foo.bar@example.co.jp まで",
+				want: []atom{
+					{Surface: "foo.bar@example.co.jp", POS: []string{"メール"}, Position: 10, Start: 4, End: 25},
+				},
+			},
+			{
+				input: "今日は#晴れ ＃東京2024 だ #123",
+				want: []atom{
+					{Surface: "#晴れ", POS: []string{"ハッシュタグ"}, Position: 9, Start: 3, End: 6},
+					{Surface: "＃東京2024", POS: []string{"ハッシュタグ"}, Position: 17, Start: 7, End: 14},
+				},
+			},
+			{
+				input: "最高😀👍🏽🇯🇵👨‍👩‍👧",
+				want: []atom{
+					{Surface: "😀", POS: []string{"絵文字"}, Position: 6, Start: 2, End: 3},
+					{Surface: "👍🏽", POS: []string{"絵文字"}, Position: 10, Start: 3, End: 5},
+					{Surface: "🇯🇵", POS: []string{"絵文字"}, Position: 18, Start: 5, End: 7},
+					{Surface: "👨‍👩‍👧", POS: []string{"絵文字"}, Position: 26, Start: 7, End: 12},
+				},
+			},
+			{
+				input: "https://example.com/#tag",
+				want: []atom{
+					{Surface: "https://example.com/#tag", POS: []string{"URL"}, Position: 0, Start: 0, End: 24},
+				},
+			},
+		}
+		for _, v := range testdata {
+			var got []atom
+			for _, tok := range tnz.Tokenize(v.input) {
+				if tok.Class != SYNTHETIC {
+					continue
+				}
+				got = append(got, atom{
+					Surface:  tok.Surface,
+					POS:      tok.POS(),
+					Position: tok.Position,
+					Start:    tok.Start,
+					End:      tok.End,
+				})
+				if base, ok := tok.BaseForm(); !ok || base != tok.Surface {
+					t.Errorf("input %q: base form of %q, got %q, %v", v.input, tok.Surface, base, ok)
+				}
+			}
+			if !reflect.DeepEqual(got, v.want) {
+				t.Errorf("input %q: got %+v, want %+v", v.input, got, v.want)
+			}
+		}
+	})
+	t.Run("user pattern", func(t *testing.T) {
+		p := AtomicPattern{
+			Regexp: regexp.MustCompile(`[A-Z]{2,}-[0-9]+`),
+			POS:    []string{"名詞", "固有名詞", "チケット"},
+		}
+		tnz, err := New(d, OmitBosEos(), AtomicPatterns(p))
+		if err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		got := tnz.Wakati("KAGOME-123を直した")
+		if want := []string{"KAGOME-123", "を", "直し", "た"}; !reflect.DeepEqual(got, want) {
+			t.Errorf("got %v, want %v", got, want)
+		}
+		tokens := tnz.Tokenize("KAGOME-123を直した")
+		if got, want := tokens[0].POS(), p.POS; !reflect.DeepEqual(got, want) {
+			t.Errorf("pos: got %v, want %v", got, want)
+		}
+	})
+	t.Run("without patterns", func(t *testing.T) {
+		tnz, err := New(d, OmitBosEos())
+		if err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		for _, tok := range tnz.Tokenize("https://example.com") {
+			if tok.Class == SYNTHETIC {
+				t.Errorf("unexpected synthetic token, %+v", tok)
+			}
+		}
+	})
+}
+
+func Test_AtomicSpans(t *testing.T) {
+	ps := []AtomicPattern{
+		{Regexp: regexp.MustCompile(`ab`)},
+		{Regexp: regexp.MustCompile(`abc`)},
+		{Regexp: regexp.MustCompile(`cd`)},
+		{Regexp: regexp.MustCompile(`x*`)},
+	}
+	got := atomicSpans("abcd cd", ps)
+	want := []struct{ ID, Start, End int }{{1, 0, 3}, {2, 5, 7}}
+	if len(got) != len(want) {
+		t.Fatalf("got %+v, want %+v", got, want)
+	}
+	for i := range want {
+		if got[i].ID != want[i].ID || got[i].Start != want[i].Start || got[i].End != want[i].End {
+			t.Errorf("got %+v, want %+v", got[i], want[i])
+		}
+	}
+}
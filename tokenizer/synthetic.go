@@ -0,0 +1,56 @@
+package tokenizer
+
+import (
+	"unicode/utf8"
+
+	"github.com/ikawaha/kagome-dict/dict"
+)
+
+// SyntheticFeatures represents the features of a token which is not backed
+// by a dictionary.
+type SyntheticFeatures struct {
+	POS           []string
+	BaseForm      string
+	Reading       string
+	Pronunciation string
+}
+
+// NewSyntheticToken returns a token of the SYNTHETIC class. The features of
+// the token are the POS followed by the base form, the reading and the
+// pronunciation. The empty base form, reading or pronunciation is treated as
+// missing. The offsets of the token start at 0, set them to place the token
+// in a sentence.
+func NewSyntheticToken(surface string, f SyntheticFeatures) Token {
+	f.POS = append([]string(nil), f.POS...)
+	return Token{
+		Class:   SYNTHETIC,
+		End:     utf8.RuneCountInString(surface),
+		Surface: surface,
+		extra:   &f,
+	}
+}
+
+func (f *SyntheticFeatures) features() []string {
+	if f == nil {
+		return nil
+	}
+	ret := make([]string, 0, len(f.POS)+3)
+	ret = append(ret, f.POS...)
+	return append(ret, f.BaseForm, f.Reading, f.Pronunciation)
+}
+
+func (f *SyntheticFeatures) pickup(key string) (string, bool) {
+	if f == nil {
+		return "", false
+	}
+	var ret string
+	switch key {
+	case dict.BaseFormIndex:
+		ret = f.BaseForm
+	case dict.ReadingIndex:
+		ret = f.Reading
+	case dict.PronunciationIndex:
+		ret = f.Pronunciation
+	}
+	return ret, ret != ""
+}
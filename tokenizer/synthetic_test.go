@@ -0,0 +1,50 @@
+package tokenizer
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_SyntheticToken(t *testing.T) {
+	pos := []string{"名詞", "固有名詞"}
+	tok := NewSyntheticToken("情報処理技術者試験", SyntheticFeatures{
+		POS:      pos,
+		BaseForm: "情報処理技術者試験",
+		Reading:  "ジョウホウショリギジュツシャシケン",
+	})
+	pos[0] = "動詞" // the token does not share the slice.
+
+	if got, want := tok.Class, SYNTHETIC; got != want {
+		t.Errorf("class: got %v, want %v", got, want)
+	}
+	if got, want := tok.End, 9; got != want {
+		t.Errorf("end: got %v, want %v", got, want)
+	}
+	if got, want := tok.POS(), []string{"名詞", "固有名詞"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("pos: got %v, want %v", got, want)
+	}
+	want := []string{"名詞", "固有名詞", "情報処理技術者試験", "ジョウホウショリギジュツシャシケン", ""}
+	if got := tok.Features(); !reflect.DeepEqual(got, want) {
+		t.Errorf("features: got %v, want %v", got, want)
+	}
+	for i, v := range want {
+		if got, ok := tok.FeatureAt(i); !ok || got != v {
+			t.Errorf("feature at %d: got %v, %v, want %v", i, got, ok, v)
+		}
+	}
+	if _, ok := tok.FeatureAt(len(want)); ok {
+		t.Errorf("feature at %d: unexpected feature", len(want))
+	}
+	if got, ok := tok.Reading(); !ok || got != "ジョウホウショリギジュツシャシケン" {
+		t.Errorf("reading: got %v, %v", got, ok)
+	}
+	if got, ok := tok.Pronunciation(); ok {
+		t.Errorf("pronunciation: got %v, want missing", got)
+	}
+	if _, ok := tok.InflectionalForm(); ok {
+		t.Errorf("inflectional form: want missing")
+	}
+	if got := NewTokenData(tok); got.Class != "SYNTHETIC" || got.BaseForm != "情報処理技術者試験" {
+		t.Errorf("token data: got %+v", got)
+	}
+}
@@ -20,6 +20,10 @@ const (
 	UNKNOWN = TokenClass(lattice.UNKNOWN)
 	// USER represents the token in the user dictionary.
 	USER = TokenClass(lattice.USER)
+	// SYNTHETIC represents the token which is not backed by a dictionary,
+	// e.g. a compound of tokens made by a filter or an atomic token made by
+	// the pre-tokenization.
+	SYNTHETIC = TokenClass(lattice.SYNTHETIC)
 )
 
 // String returns string representation of a token class.
@@ -34,6 +38,8 @@ func (c TokenClass) String() string {
 		ret = "UNKNOWN"
 	case USER:
 		ret = "USER"
+	case SYNTHETIC:
+		ret = "SYNTHETIC"
 	}
 	return ret
 }
@@ -49,6 +55,7 @@ type Token struct {
 	Surface  string
 	dict     *dict.Dict
 	udict    *dict.UserDict
+	extra    *SyntheticFeatures
 }
 
 // Features returns contents of a token.
@@ -78,6 +85,8 @@ func (t Token) Features() []string {
 		tokens := strings.Join(t.udict.Contents[t.ID].Tokens, "/")
 		yomi := strings.Join(t.udict.Contents[t.ID].Yomi, "/")
 		return []string{pos, tokens, yomi}
+	case SYNTHETIC:
+		return t.extra.features()
 	}
 	return nil
 }
@@ -127,6 +136,10 @@ func (t Token) FeatureAt(i int) (string, bool) {
 		case 2:
 			return strings.Join(t.udict.Contents[t.ID].Yomi, "/"), true
 		}
+	case SYNTHETIC:
+		if c := t.extra.features(); i < len(c) {
+			return c[i], true
+		}
 	}
 	return "", false
 }
@@ -182,6 +195,11 @@ func (t Token) POS() []string {
 	case USER:
 		pos := t.udict.Contents[t.ID].Pos
 		return []string{pos}
+	case SYNTHETIC:
+		if t.extra == nil {
+			return nil
+		}
+		return append([]string(nil), t.extra.POS...)
 	}
 	return nil
 }
@@ -241,6 +259,8 @@ func (t Token) pickupFromFeatures(key string) (string, bool) {
 		meta = t.dict.ContentsMeta
 	case UNKNOWN:
 		meta = t.dict.UnkDict.ContentsMeta
+	case SYNTHETIC:
+		return t.extra.pickup(key)
 	}
 	i, ok := meta[key]
 	if !ok {
@@ -21,6 +21,7 @@ func Test_TokenClassString(t *testing.T) {
 		{inp: KNOWN, out: "KNOWN"},
 		{inp: UNKNOWN, out: "UNKNOWN"},
 		{inp: USER, out: "USER"},
+		{inp: SYNTHETIC, out: "SYNTHETIC"},
 	}
 
 	for _, v := range testdata {
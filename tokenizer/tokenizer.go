@@ -46,6 +46,8 @@ type Tokenizer struct {
 	dict       *dict.Dict     // system dictionary
 	userDict   *dict.UserDict // user dictionary
 	omitBosEos bool           // omit BOS/EOS
+
+	atomicPatterns []AtomicPattern // patterns of the atomic tokens
 }
 
 // New creates a tokenizer.
@@ -83,7 +85,7 @@ func (t Tokenizer) Wakati(input string) []string {
 func (t Tokenizer) Analyze(input string, mode TokenizeMode) []Token {
 	la := lattice.New(t.dict, t.userDict)
 	defer la.Free()
-	la.Build(input)
+	la.BuildWithSpans(input, atomicSpans(input, t.atomicPatterns))
 	m := lattice.Normal
 	switch mode {
 	case Normal:
@@ -113,6 +115,9 @@ func (t Tokenizer) Analyze(input string, mode TokenizeMode) []Token {
 			dict:     t.dict,
 			udict:    t.userDict,
 		}
+		if tok.Class == SYNTHETIC {
+			tok.extra = t.atomicFeatures(n)
+		}
 		if tok.ID == BosEosID {
 			if i == 0 {
 				tok.Surface = "BOS"
@@ -134,7 +139,7 @@ func (t Tokenizer) Dot(w io.Writer, input string) (tokens []Token) {
 func (t Tokenizer) AnalyzeGraph(w io.Writer, input string, mode TokenizeMode) []Token {
 	la := lattice.New(t.dict, t.userDict)
 	defer la.Free()
-	la.Build(input)
+	la.BuildWithSpans(input, atomicSpans(input, t.atomicPatterns))
 	m := lattice.Normal
 	switch mode {
 	case Normal:
@@ -159,6 +164,9 @@ func (t Tokenizer) AnalyzeGraph(w io.Writer, input string, mode TokenizeMode) []
 			dict:    t.dict,
 			udict:   t.userDict,
 		}
+		if tok.Class == SYNTHETIC {
+			tok.extra = t.atomicFeatures(n)
+		}
 		if tok.ID == lattice.BosEosID {
 			if i == 0 {
 				tok.Surface = "BOS"
@@ -34,3 +34,18 @@ func OmitBosEos() Option {
 		return nil
 	}
 }
+
+// AtomicPatterns is a tokenizer option to tokenize the spans which match
+// the patterns as single tokens, e.g. URLs, emails, hashtags and emoji.
+// See DefaultAtomicPatterns for the built-in patterns.
+func AtomicPatterns(ps ...AtomicPattern) Option {
+	return func(t *Tokenizer) error {
+		for _, p := range ps {
+			if p.Regexp == nil {
+				return errors.New("empty atomic pattern")
+			}
+		}
+		t.atomicPatterns = append(t.atomicPatterns, ps...)
+		return nil
+	}
+}
@@ -0,0 +1,2 @@
+// Package train re-estimates the costs of a dictionary from an annotated corpus.
+package train
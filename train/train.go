@@ -0,0 +1,218 @@
+package train
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/corpus"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// DefaultRate is the default amount of a cost update.
+const DefaultRate = 10
+
+// ErrUnknownToken is returned if a token of a gold sentence is not found in
+// the dictionary.
+var ErrUnknownToken = errors.New("token not found in the dictionary")
+
+// Trainer re-estimates word costs and connection costs of a dictionary with
+// the structured perceptron over the lattice of the tokenizer.
+//
+// Word costs of the known words and all connection costs are updated.
+// The costs of unknown words and user dictionary entries are left as they are.
+type Trainer struct {
+	// Rate is the amount of a cost update.
+	Rate int
+
+	dict      *dict.Dict
+	tokenizer *tokenizer.Tokenizer
+}
+
+// New returns a trainer. The trainer works on a copy of the costs of the
+// given dictionary, so the dictionary is not modified.
+func New(d *dict.Dict) (*Trainer, error) {
+	if d == nil {
+		return nil, errors.New("empty dictionary")
+	}
+	w := *d
+	w.Morphs = append(dict.Morphs(nil), d.Morphs...)
+	w.Connection.Vec = append([]int16(nil), d.Connection.Vec...)
+	t, err := tokenizer.New(&w, tokenizer.OmitBosEos())
+	if err != nil {
+		return nil, err
+	}
+	return &Trainer{
+		Rate:      DefaultRate,
+		dict:      &w,
+		tokenizer: t,
+	}, nil
+}
+
+// Dict returns the dictionary with the trained costs.
+func (t *Trainer) Dict() *dict.Dict {
+	return t.dict
+}
+
+// node represents a node of a path, that is, an entry of the dictionary and
+// its connection IDs.
+type node struct {
+	id          int
+	left, right int
+}
+
+// Update tokenizes the text of a gold sentence and updates the costs if the
+// result differs from the gold. It returns true if the result is correct.
+func (t *Trainer) Update(s corpus.Sentence) (bool, error) {
+	gold, err := t.goldPath(s)
+	if err != nil {
+		return false, err
+	}
+	var (
+		pred  []node
+		known = true
+	)
+	for _, v := range t.tokenizer.Tokenize(s.Text()) {
+		var m dict.Morph
+		switch v.Class {
+		case tokenizer.KNOWN:
+			m = t.dict.Morphs[v.ID]
+		case tokenizer.UNKNOWN:
+			m = t.dict.UnkDict.Morphs[v.ID]
+			known = false
+		}
+		n := node{id: v.ID, left: int(m.LeftID), right: int(m.RightID)}
+		if v.Class != tokenizer.KNOWN {
+			n.id = -1
+		}
+		pred = append(pred, n)
+	}
+	if known && equalPath(gold, pred) {
+		return true, nil
+	}
+	t.update(gold, -t.Rate)
+	t.update(pred, t.Rate)
+	return false, nil
+}
+
+// goldPath maps the tokens of a gold sentence to the dictionary entries.
+// An entry with the same features is preferred, then an entry with the same
+// part-of-speech.
+func (t *Trainer) goldPath(s corpus.Sentence) ([]node, error) {
+	ret := make([]node, 0, len(s))
+	for _, v := range s {
+		id := -1
+		for _, x := range t.dict.Index.Search(v.Surface) {
+			features := t.features(x)
+			if tokenizer.EqualFeatures(features, v.Features) {
+				id = x
+				break
+			}
+			if n := len(t.dict.POSTable.POSs[x]); id < 0 && len(v.Features) >= n &&
+				tokenizer.EqualFeatures(features[:n], v.Features[:n]) {
+				id = x
+			}
+		}
+		if id < 0 {
+			return nil, fmt.Errorf("%w, %s %v", ErrUnknownToken, v.Surface, v.Features)
+		}
+		m := t.dict.Morphs[id]
+		ret = append(ret, node{id: id, left: int(m.LeftID), right: int(m.RightID)})
+	}
+	return ret, nil
+}
+
+func (t *Trainer) features(id int) []string {
+	pos := t.dict.POSTable.POSs[id]
+	ret := make([]string, 0, len(pos))
+	for _, v := range pos {
+		ret = append(ret, t.dict.POSTable.NameList[v])
+	}
+	if id < len(t.dict.Contents) {
+		ret = append(ret, t.dict.Contents[id]...)
+	}
+	return ret
+}
+
+// update adds the delta to the costs of the nodes and the connections of a
+// path including the connections from BOS and to EOS.
+func (t *Trainer) update(path []node, delta int) {
+	right := 0 // BOS
+	for _, v := range path {
+		t.addConnection(right, v.left, delta)
+		if v.id >= 0 {
+			m := &t.dict.Morphs[v.id]
+			m.Weight = clamp(int(m.Weight) + delta)
+		}
+		right = v.right
+	}
+	t.addConnection(right, 0, delta) // EOS
+}
+
+func (t *Trainer) addConnection(right, left, delta int) {
+	c := &t.dict.Connection
+	i := c.Row*int64(left) + int64(right) // connection matrix is transposed
+	c.Vec[i] = clamp(int(c.Vec[i]) + delta)
+}
+
+func equalPath(lhs, rhs []node) bool {
+	if len(lhs) != len(rhs) {
+		return false
+	}
+	for i := range lhs {
+		if lhs[i] != rhs[i] {
+			return false
+		}
+	}
+	return true
+}
+
+func clamp(v int) int16 {
+	if v > math.MaxInt16 {
+		return math.MaxInt16
+	}
+	if v < math.MinInt16 {
+		return math.MinInt16
+	}
+	return int16(v)
+}
+
+// Stat represents the statistics of an epoch.
+type Stat struct {
+	Epoch     int
+	Sentences int // number of trained sentences
+	Errors    int // number of sentences which were tokenized incorrectly
+	Skipped   int // number of sentences which have a token not in the dictionary
+}
+
+// Train trains the costs over the corpus for the given number of epochs and
+// returns the statistics of each epoch. The number of epochs must be
+// positive.
+func (t *Trainer) Train(c []corpus.Sentence, epochs int) ([]Stat, error) {
+	if epochs <= 0 {
+		return nil, fmt.Errorf("invalid number of epochs, %d", epochs)
+	}
+	ret := make([]Stat, 0, epochs)
+	for i := 1; i <= epochs; i++ {
+		st := Stat{Epoch: i}
+		for _, s := range c {
+			ok, err := t.Update(s)
+			if errors.Is(err, ErrUnknownToken) {
+				st.Skipped++
+				continue
+			} else if err != nil {
+				return ret, err
+			}
+			st.Sentences++
+			if !ok {
+				st.Errors++
+			}
+		}
+		ret = append(ret, st)
+		if st.Errors == 0 {
+			break
+		}
+	}
+	return ret, nil
+}
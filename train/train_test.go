@@ -0,0 +1,110 @@
+package train_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/corpus"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+	"github.com/ikawaha/kagome/v2/train"
+)
+
+const testDictPath = "../testdata/ipa.dict"
+
+const goldCorpus = `関西	名詞,固有名詞,地域,一般,*,*,関西,カンサイ,カンサイ
+国際	名詞,一般,*,*,*,*,国際,コクサイ,コクサイ
+空港	名詞,一般,*,*,*,*,空港,クウコウ,クーコー
+EOS
+`
+
+func surfaces(tokens []tokenizer.Token) string {
+	var ret []string
+	for _, v := range tokens {
+		ret = append(ret, v.Surface)
+	}
+	return strings.Join(ret, "/")
+}
+
+func TestTrainer_Train(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	c, err := corpus.ReadMeCab(strings.NewReader(goldCorpus))
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	before, err := tokenizer.New(d, tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if got, want := surfaces(before.Tokenize("関西国際空港")), "関西国際空港"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+
+	tr, err := train.New(d)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tr.Rate = 100
+	for _, epochs := range []int{0, -1} {
+		if _, err := tr.Train(c, epochs); err == nil {
+			t.Errorf("epochs %d: expected error", epochs)
+		}
+	}
+	stats, err := tr.Train(c, 100)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if len(stats) < 2 {
+		t.Fatalf("expected some updates, %+v", stats)
+	}
+	if last := stats[len(stats)-1]; last.Errors != 0 || last.Sentences != 1 {
+		t.Errorf("not converged, %+v", last)
+	}
+
+	after, err := tokenizer.New(tr.Dict(), tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if got, want := surfaces(after.Tokenize("関西国際空港")), "関西/国際/空港"; got != want {
+		t.Errorf("got %q, want %q", got, want)
+	}
+	if got, want := surfaces(before.Tokenize("関西国際空港")), "関西国際空港"; got != want {
+		t.Errorf("the original dictionary is modified, got %q, want %q", got, want)
+	}
+}
+
+func TestTrainer_Update(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tr, err := train.New(d)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	t.Run("correct", func(t *testing.T) {
+		s := corpus.Sentence{
+			{Surface: "ねこ", Features: []string{"名詞", "一般", "*", "*", "*", "*", "ねこ", "ネコ", "ネコ"}},
+			{Surface: "です", Features: []string{"助動詞", "*", "*", "*", "特殊・デス", "基本形", "です", "デス", "デス"}},
+		}
+		ok, err := tr.Update(s)
+		if err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		if !ok {
+			t.Error("expected correct, but incorrect")
+		}
+	})
+	t.Run("unknown token", func(t *testing.T) {
+		s := corpus.Sentence{
+			{Surface: "ポポピ", Features: []string{"名詞", "一般", "*", "*", "*", "*", "*"}},
+		}
+		if _, err := tr.Update(s); !errors.Is(err, train.ErrUnknownToken) {
+			t.Errorf("got %v, want %v", err, train.ErrUnknownToken)
+		}
+	})
+}
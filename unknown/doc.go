@@ -0,0 +1,3 @@
+// Package unknown mines unknown words and suspicious splits from tokenized
+// sentences as user dictionary entry candidates.
+package unknown
@@ -0,0 +1,262 @@
+package unknown
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+// Kind represents why a candidate was collected.
+type Kind int
+
+const (
+	// Unknown is an unknown word of the dictionary.
+	Unknown Kind = iota + 1
+	// SingleChars is a run of single-character tokens.
+	SingleChars
+	// KatakanaSplit is a katakana sequence cut into pieces.
+	KatakanaSplit
+)
+
+// String returns a string representation of a kind.
+func (k Kind) String() string {
+	switch k {
+	case Unknown:
+		return "unknown"
+	case SingleChars:
+		return "single-chars"
+	case KatakanaSplit:
+		return "katakana-split"
+	}
+	return "undefined"
+}
+
+// Candidate represents a user dictionary entry candidate. The reading is
+// empty if it cannot be estimated.
+type Candidate struct {
+	Surface  string
+	Reading  string
+	Kind     Kind
+	Count    int
+	Contexts int
+}
+
+// Miner collects user dictionary entry candidates from tokenized sentences.
+type Miner struct {
+	tokenizer  *tokenizer.Tokenizer
+	mode       tokenizer.TokenizeMode
+	candidates map[string]*candidate
+}
+
+type candidate struct {
+	Candidate
+	contexts map[string]struct{}
+}
+
+// NewMiner returns a miner which tokenizes sentences with the tokenizer.
+// The tokenizer should omit BOS/EOS tokens.
+func NewMiner(t *tokenizer.Tokenizer, mode tokenizer.TokenizeMode) *Miner {
+	return &Miner{
+		tokenizer:  t,
+		mode:       mode,
+		candidates: map[string]*candidate{},
+	}
+}
+
+// Add tokenizes a sentence and collects the candidates in it.
+func (m *Miner) Add(sentence string) {
+	tokens := m.tokenizer.Analyze(sentence, m.mode)
+	for i := 0; i < len(tokens); {
+		j, kind := i+1, Kind(0)
+		switch {
+		case isKatakana(tokens[i].Surface):
+			for j < len(tokens) && isKatakana(tokens[j].Surface) {
+				j++
+			}
+			if j-i > 1 {
+				kind = KatakanaSplit
+			}
+		case isSingleChar(tokens[i].Surface):
+			for j < len(tokens) && isSingleChar(tokens[j].Surface) {
+				j++
+			}
+			if j-i > 1 {
+				kind = SingleChars
+			}
+		}
+		if kind == 0 {
+			j = i + 1
+			if tokens[i].Class == tokenizer.UNKNOWN && hasLetter(tokens[i].Surface) {
+				kind = Unknown
+			}
+		}
+		if kind != 0 {
+			m.add(kind, tokens, i, j)
+		}
+		i = j
+	}
+}
+
+func (m *Miner) add(kind Kind, tokens []tokenizer.Token, begin, end int) {
+	var b strings.Builder
+	for _, v := range tokens[begin:end] {
+		b.WriteString(v.Surface)
+	}
+	surface := b.String()
+	c, ok := m.candidates[surface]
+	if !ok {
+		c = &candidate{
+			Candidate: Candidate{
+				Surface: surface,
+				Kind:    kind,
+			},
+			contexts: map[string]struct{}{},
+		}
+		if r, ok := m.reading(tokens[begin:end]); ok {
+			c.Reading = r
+		}
+		m.candidates[surface] = c
+	}
+	c.Count++
+	left, right := "BOS", "EOS"
+	if begin > 0 {
+		left = tokens[begin-1].Surface
+	}
+	if end < len(tokens) {
+		right = tokens[end].Surface
+	}
+	c.contexts[left+"\t"+right] = struct{}{}
+}
+
+// reading returns the reading of the tokens, or estimates it from the
+// surface if some of the tokens do not have a reading. It returns false if
+// the reading cannot be estimated.
+func (m *Miner) reading(tokens []tokenizer.Token) (string, bool) {
+	var b strings.Builder
+	for _, v := range tokens {
+		r, ok := tokenReading(v)
+		if !ok {
+			b.Reset()
+			for _, v := range tokens {
+				b.WriteString(v.Surface)
+			}
+			return m.estimateReading(b.String())
+		}
+		b.WriteString(r)
+	}
+	return b.String(), true
+}
+
+// estimateReading converts kana to katakana and replaces the other parts
+// with the readings of the longest dictionary entries. It returns false if
+// some characters have no entries.
+func (m *Miner) estimateReading(s string) (string, bool) {
+	var b strings.Builder
+	for len(s) > 0 {
+		r, size := utf8.DecodeRuneInString(s)
+		if unicode.In(r, unicode.Hiragana, unicode.Katakana) || r == 'ー' {
+			b.WriteRune(toKatakana(r))
+			s = s[size:]
+			continue
+		}
+		var (
+			reading string
+			length  int
+			cost    int
+		)
+		for _, v := range m.tokenizer.Lookup(s, tokenizer.CommonPrefix, 0) {
+			r, ok := tokenReading(v.Token)
+			if !ok {
+				continue
+			}
+			if l := len(v.Surface); l > length || l == length && v.Cost < cost {
+				reading, length, cost = r, l, v.Cost
+			}
+		}
+		if length == 0 {
+			return "", false
+		}
+		b.WriteString(reading)
+		s = s[length:]
+	}
+	return b.String(), true
+}
+
+// Candidates returns the candidates which appeared at least minCount times,
+// ordered by the frequency and the number of distinct contexts.
+func (m *Miner) Candidates(minCount int) []Candidate {
+	ret := make([]Candidate, 0, len(m.candidates))
+	for _, v := range m.candidates {
+		if v.Count < minCount {
+			continue
+		}
+		c := v.Candidate
+		c.Contexts = len(v.contexts)
+		ret = append(ret, c)
+	}
+	sort.Slice(ret, func(i, j int) bool {
+		if ret[i].Count != ret[j].Count {
+			return ret[i].Count > ret[j].Count
+		}
+		if ret[i].Contexts != ret[j].Contexts {
+			return ret[i].Contexts > ret[j].Contexts
+		}
+		return ret[i].Surface < ret[j].Surface
+	})
+	return ret
+}
+
+func tokenReading(t tokenizer.Token) (string, bool) {
+	if r, ok := t.Reading(); ok && r != "*" {
+		return r, true
+	}
+	if r, ok := t.Pronunciation(); ok && r != "*" {
+		return r, true
+	}
+	if isKatakana(t.Surface) {
+		return t.Surface, true
+	}
+	return "", false
+}
+
+func toKatakana(r rune) rune {
+	if r >= 'ぁ' && r <= 'ゖ' {
+		return r + 'ァ' - 'ぁ'
+	}
+	return r
+}
+
+func isKatakana(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if !unicode.In(r, unicode.Katakana) && r != 'ー' {
+			return false
+		}
+	}
+	return true
+}
+
+// isSingleChar reports whether s is a single letter which is not kana.
+// Single hiragana tokens are mostly particles, and katakana are handled
+// as katakana sequences.
+func isSingleChar(s string) bool {
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 || size != len(s) {
+		return false
+	}
+	return unicode.IsLetter(r) && !unicode.In(r, unicode.Hiragana, unicode.Katakana)
+}
+
+func hasLetter(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,65 @@
+package unknown
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/tokenizer"
+)
+
+const testDictPath = "../testdata/ipa.dict"
+
+func newTestMiner(t *testing.T) *Miner {
+	t.Helper()
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	tnz, err := tokenizer.New(d, tokenizer.OmitBosEos())
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	return NewMiner(tnz, tokenizer.Normal)
+}
+
+func TestMiner_Candidates(t *testing.T) {
+	m := newTestMiner(t)
+	for _, v := range []string{
+		"檸檬爆弾",
+		"檸檬を買う",
+		"kagomeで解析",
+		"kagomeで解析",
+		"データサイエンスの勉強",
+		"データサイエンスの勉強",
+	} {
+		m.Add(v)
+	}
+	want := []Candidate{
+		{Surface: "檸檬", Reading: "", Kind: SingleChars, Count: 2, Contexts: 2},
+		{Surface: "kagome", Reading: "", Kind: Unknown, Count: 2, Contexts: 1},
+		{Surface: "データサイエンス", Reading: "データサイエンス", Kind: KatakanaSplit, Count: 2, Contexts: 1},
+	}
+	if got := m.Candidates(2); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %+v, want %+v", got, want)
+	}
+}
+
+func TestMiner_estimateReading(t *testing.T) {
+	m := newTestMiner(t)
+	testdata := []struct {
+		input string
+		want  string
+		ok    bool
+	}{
+		{input: "形態素かいせき", want: "ケイタイソカイセキ", ok: true},
+		{input: "形態素かいせきKagome", ok: false},
+		{input: "檸檬", ok: false},
+	}
+	for _, v := range testdata {
+		got, ok := m.estimateReading(v.input)
+		if got != v.want || ok != v.ok {
+			t.Errorf("input %q: got %q, %v, want %q, %v", v.input, got, ok, v.want, v.ok)
+		}
+	}
+}
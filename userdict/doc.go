@@ -0,0 +1,2 @@
+// Package userdict validates, compiles and loads user dictionaries.
+package userdict
@@ -0,0 +1,88 @@
+package userdict
+
+import (
+	"archive/zip"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ikawaha/kagome-dict/dict"
+)
+
+const (
+	// IndexFileName is the file name of the index part of a compiled user dictionary.
+	IndexFileName = dict.IndexDictFileName
+	// ContentsFileName is the file name of the contents part of a compiled user dictionary.
+	ContentsFileName = "user_contents.dict"
+)
+
+// Save saves a user dictionary in a zipped binary format which loads faster
+// than the text format.
+func Save(w io.Writer, d *dict.UserDict) error {
+	if d == nil {
+		return errors.New("empty user dictionary")
+	}
+	zw := zip.NewWriter(w)
+	f, err := zw.Create(IndexFileName)
+	if err != nil {
+		return fmt.Errorf("create file error, %q, %w", IndexFileName, err)
+	}
+	if _, err := d.Index.WriteTo(f); err != nil {
+		return fmt.Errorf("write error, %q, %w", IndexFileName, err)
+	}
+	f, err = zw.Create(ContentsFileName)
+	if err != nil {
+		return fmt.Errorf("create file error, %q, %w", ContentsFileName, err)
+	}
+	if err := gob.NewEncoder(f).Encode(d.Contents); err != nil {
+		return fmt.Errorf("write error, %q, %w", ContentsFileName, err)
+	}
+	return zw.Close()
+}
+
+// Read reads a user dictionary in the zipped binary format.
+func Read(r *zip.Reader) (*dict.UserDict, error) {
+	var (
+		ret             dict.UserDict
+		index, contents bool
+	)
+	for _, f := range r.File {
+		rc, err := f.Open()
+		if err != nil {
+			return nil, err
+		}
+		switch f.Name {
+		case IndexFileName:
+			ret.Index, err = dict.ReadIndexTable(rc)
+			index = true
+		case ContentsFileName:
+			err = gob.NewDecoder(rc).Decode(&ret.Contents)
+			contents = true
+		default:
+			err = errors.New("unknown file")
+		}
+		_ = rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("%q, %w", f.Name, err)
+		}
+	}
+	if !index || !contents {
+		return nil, errors.New("broken user dictionary")
+	}
+	return &ret, nil
+}
+
+// Load loads a user dictionary from a file. The file is either a compiled
+// binary written by Save or a user dictionary in the text format.
+func Load(path string) (*dict.UserDict, error) {
+	r, err := zip.OpenReader(path)
+	if errors.Is(err, zip.ErrFormat) {
+		return dict.NewUserDict(path)
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+	return Read(&r.Reader)
+}
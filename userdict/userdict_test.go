@@ -0,0 +1,58 @@
+package userdict_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/userdict"
+)
+
+const testUserDictPath = "../testdata/userdict.txt"
+
+func TestSaveAndLoad(t *testing.T) {
+	want, err := dict.NewUserDict(testUserDictPath)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "userdict.bin")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if err := userdict.Save(f, want); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if err := f.Close(); err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+
+	t.Run("compiled", func(t *testing.T) {
+		got, err := userdict.Load(path)
+		if err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		if !reflect.DeepEqual(got.Contents, want.Contents) {
+			t.Errorf("got %+v, want %+v", got.Contents, want.Contents)
+		}
+		if ids := got.Index.Search("関西国際空港"); !reflect.DeepEqual(ids, want.Index.Search("関西国際空港")) {
+			t.Errorf("got %v, want %v", ids, want.Index.Search("関西国際空港"))
+		}
+	})
+	t.Run("text", func(t *testing.T) {
+		got, err := userdict.Load(testUserDictPath)
+		if err != nil {
+			t.Fatalf("unexpected error, %v", err)
+		}
+		if !reflect.DeepEqual(got.Contents, want.Contents) {
+			t.Errorf("got %+v, want %+v", got.Contents, want.Contents)
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		if _, err := userdict.Load(filepath.Join(t.TempDir(), "not_found")); err == nil {
+			t.Error("expected error, but no error")
+		}
+	})
+}
@@ -0,0 +1,161 @@
+package userdict
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/ikawaha/kagome-dict/dict"
+)
+
+// Severity represents the severity of a diagnostic.
+type Severity int
+
+const (
+	// Warning means that the entry is accepted but may not work as intended.
+	Warning Severity = iota + 1
+	// Error means that the entry is rejected by the user dictionary builder.
+	Error
+)
+
+// String returns a string representation of a severity.
+func (s Severity) String() string {
+	switch s {
+	case Warning:
+		return "warning"
+	case Error:
+		return "error"
+	}
+	return fmt.Sprintf("unknown severity (%d)", s)
+}
+
+// Diagnostic represents a problem found in a line of a user dictionary.
+type Diagnostic struct {
+	Line     int // 1-origin line number
+	Severity Severity
+	Message  string
+}
+
+// String returns a string representation of a diagnostic.
+func (d Diagnostic) String() string {
+	return fmt.Sprintf("%d: %v: %s", d.Line, d.Severity, d.Message)
+}
+
+// Result represents the result of a validation.
+type Result struct {
+	Diagnostics []Diagnostic
+	Records     dict.UserDictRecords // records without errors
+}
+
+// HasError returns true if the result has any error diagnostics.
+func (r Result) HasError() bool {
+	for _, v := range r.Diagnostics {
+		if v.Severity == Error {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate reads a user dictionary and reports line-numbered diagnostics.
+// If a system dictionary is given, entries that shadow the system dictionary
+// entries are reported as warnings.
+func Validate(r io.Reader, sys *dict.Dict) (*Result, error) {
+	var (
+		ret  Result
+		defs = map[string]int{} // surface -> line number
+		recs = map[string]dict.UserDicRecord{}
+	)
+	s := bufio.NewScanner(r)
+	for no := 1; s.Scan(); no++ {
+		line := s.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		report := func(sev Severity, format string, a ...any) {
+			ret.Diagnostics = append(ret.Diagnostics, Diagnostic{
+				Line:     no,
+				Severity: sev,
+				Message:  fmt.Sprintf(format, a...),
+			})
+		}
+		vec := strings.Split(line, ",")
+		if len(vec) != dict.UserDictColumnSize {
+			report(Error, "invalid format: want %d columns, got %d", dict.UserDictColumnSize, len(vec))
+			continue
+		}
+		rec := dict.UserDicRecord{
+			Text:   vec[0],
+			Tokens: strings.Split(vec[1], " "),
+			Yomi:   strings.Split(vec[2], " "),
+			Pos:    vec[3],
+		}
+		surface := strings.TrimSpace(rec.Text)
+		if surface == "" {
+			report(Error, "empty surface")
+			continue
+		}
+		valid := true
+		if surface != rec.Text {
+			report(Warning, "surface %q has leading or trailing white spaces", rec.Text)
+		}
+		if rec.Pos == "" {
+			report(Warning, "empty part-of-speech")
+		}
+		if len(rec.Tokens) != len(rec.Yomi) {
+			report(Error, "token/reading count mismatch: %d tokens, %d readings", len(rec.Tokens), len(rec.Yomi))
+			valid = false
+		}
+		if hasEmpty(rec.Tokens) {
+			report(Error, "empty token in %q", vec[1])
+			valid = false
+		} else if v := strings.Join(rec.Tokens, ""); v != surface {
+			report(Error, "tokens %q do not concatenate to the surface %q", v, surface)
+			valid = false
+		}
+		if hasEmpty(rec.Yomi) {
+			report(Error, "empty reading in %q", vec[2])
+			valid = false
+		}
+		if prev, ok := defs[surface]; ok {
+			if equalRecord(recs[surface], rec) {
+				report(Error, "duplicate entry, same as line %d", prev)
+			} else {
+				report(Error, "conflicting entry, %q is already defined at line %d", surface, prev)
+			}
+			continue
+		}
+		if !valid {
+			continue
+		}
+		defs[surface] = no
+		recs[surface] = rec
+		if sys != nil {
+			if ids := sys.Index.Search(surface); len(ids) > 0 {
+				report(Warning, "%q shadows %d system dictionary entries", surface, len(ids))
+			}
+		}
+		ret.Records = append(ret.Records, rec)
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return &ret, nil
+}
+
+func hasEmpty(list []string) bool {
+	for _, v := range list {
+		if v == "" {
+			return true
+		}
+	}
+	return false
+}
+
+func equalRecord(lhs, rhs dict.UserDicRecord) bool {
+	return strings.TrimSpace(lhs.Text) == strings.TrimSpace(rhs.Text) &&
+		lhs.Pos == rhs.Pos &&
+		strings.Join(lhs.Tokens, " ") == strings.Join(rhs.Tokens, " ") &&
+		strings.Join(lhs.Yomi, " ") == strings.Join(rhs.Yomi, " ")
+}
@@ -0,0 +1,82 @@
+package userdict_test
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ikawaha/kagome-dict/dict"
+	"github.com/ikawaha/kagome/v2/userdict"
+)
+
+const testDictPath = "../testdata/ipa.dict"
+
+func TestValidate(t *testing.T) {
+	const input = `# comment
+朝青龍,朝青龍,アサショウリュウ,カスタム人名
+日本経済新聞,日本 経済 新聞,ニホン ケイザイ シンブン,カスタム名詞
+関西国際空港,関西 国際 空港,カンサイ コクサイ,テスト名詞
+東京スカイツリー,東京 スカイ ツリー,トウキョウ スカイツリー
+東京都庁,東京 都,トウキョウ トチョウ,カスタム名詞
+朝青龍,朝青龍,アサショウリュウ,カスタム人名
+日本経済新聞,日本経済新聞,ニホンケイザイシンブン,カスタム名詞
+
+成田空港,成田  空港,ナリタ  クウコウ,カスタム名詞
+ 羽田空港,羽田 空港,ハネダ クウコウ,
+`
+	got, err := userdict.Validate(strings.NewReader(input), nil)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	want := []string{
+		"4: error: token/reading count mismatch: 3 tokens, 2 readings",
+		"5: error: invalid format: want 4 columns, got 3",
+		`6: error: tokens "東京都" do not concatenate to the surface "東京都庁"`,
+		"7: error: duplicate entry, same as line 2",
+		`8: error: conflicting entry, "日本経済新聞" is already defined at line 3`,
+		`10: error: empty token in "成田  空港"`,
+		`10: error: empty reading in "ナリタ  クウコウ"`,
+		`11: warning: surface " 羽田空港" has leading or trailing white spaces`,
+		"11: warning: empty part-of-speech",
+	}
+	var diags []string
+	for _, v := range got.Diagnostics {
+		diags = append(diags, v.String())
+	}
+	if !reflect.DeepEqual(diags, want) {
+		t.Errorf("got %q, want %q", diags, want)
+	}
+	if !got.HasError() {
+		t.Error("expected errors, but no error")
+	}
+	var texts []string
+	for _, v := range got.Records {
+		texts = append(texts, v.Text)
+	}
+	if want := []string{"朝青龍", "日本経済新聞", " 羽田空港"}; !reflect.DeepEqual(texts, want) {
+		t.Errorf("got %q, want %q", texts, want)
+	}
+}
+
+func TestValidate_Shadowing(t *testing.T) {
+	d, err := dict.LoadDictFile(testDictPath)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	got, err := userdict.Validate(strings.NewReader("日本,日本,ニッポン,カスタム名詞\n"), d)
+	if err != nil {
+		t.Fatalf("unexpected error, %v", err)
+	}
+	if got.HasError() {
+		t.Errorf("unexpected error diagnostics, %v", got.Diagnostics)
+	}
+	if len(got.Diagnostics) != 1 {
+		t.Fatalf("got %v, want 1 diagnostic", got.Diagnostics)
+	}
+	if got, want := got.Diagnostics[0].Severity, userdict.Warning; got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+	if !strings.Contains(got.Diagnostics[0].Message, "shadows") {
+		t.Errorf("unexpected message, %q", got.Diagnostics[0].Message)
+	}
+}